@@ -1,14 +1,114 @@
 package telegram
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
 
 type ApiResult struct {
-	Ok          bool            `json:"ok"`
-	Result      json.RawMessage `json:"result"`
-	ErrorCode   int             `json:"error_code"`
-	Description string          `json:"description"`
+	Ok          bool                `json:"ok"`
+	Result      json.RawMessage     `json:"result"`
+	ErrorCode   int                 `json:"error_code"`
+	Description string              `json:"description"`
+	Parameters  *ResponseParameters `json:"parameters,omitempty"`
 }
 
 func (a *ApiResult) Error() string {
 	return a.Description
 }
+
+func (a *ApiResult) toError() *APIError {
+	return &APIError{
+		ErrorCode:   a.ErrorCode,
+		Description: a.Description,
+		Parameters:  a.Parameters,
+	}
+}
+
+// APIError is returned by Bot methods when Telegram responds with ok=false.
+type APIError struct {
+	ErrorCode   int
+	Description string
+	Parameters  *ResponseParameters
+}
+
+func (e *APIError) Error() string {
+	return e.Description
+}
+
+// RetryAfter returns how long to wait before the request can be repeated
+// after exceeding flood control, or 0.
+func (e *APIError) RetryAfter() time.Duration {
+	if e.Parameters == nil {
+		return 0
+	}
+
+	return time.Duration(e.Parameters.RetryAfter) * time.Second
+}
+
+// MigrateToChatId returns the identifier of the supergroup the group has been
+// migrated to, or 0.
+func (e *APIError) MigrateToChatId() int64 {
+	if e.Parameters == nil {
+		return 0
+	}
+
+	return e.Parameters.MigrateToChatId
+}
+
+func asAPIError(err error) *APIError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	return nil
+}
+
+func IsTooManyRequests(err error) bool {
+	apiErr := asAPIError(err)
+	return apiErr != nil && apiErr.ErrorCode == 429
+}
+
+func IsChatMigrated(err error) bool {
+	apiErr := asAPIError(err)
+	return apiErr != nil && apiErr.MigrateToChatId() != 0
+}
+
+func IsBotBlocked(err error) bool {
+	apiErr := asAPIError(err)
+	return apiErr != nil && apiErr.ErrorCode == 403 && strings.Contains(apiErr.Description, "bot was blocked by the user")
+}
+
+func IsUnauthorized(err error) bool {
+	apiErr := asAPIError(err)
+	return apiErr != nil && apiErr.ErrorCode == 401
+}
+
+// IsConflict reports whether the error is caused by another getUpdates request
+// or by a webhook set for the bot.
+func IsConflict(err error) bool {
+	apiErr := asAPIError(err)
+	return apiErr != nil && apiErr.ErrorCode == 409
+}
+
+func IsMessageNotModified(err error) bool {
+	apiErr := asAPIError(err)
+	return apiErr != nil && apiErr.ErrorCode == 400 && strings.Contains(apiErr.Description, "message is not modified")
+}
+
+func unmarshalUnions[T any](raw []json.RawMessage, unmarshal func(data []byte) (T, error)) ([]T, error) {
+	result := make([]T, len(raw))
+	for i, data := range raw {
+		it, err := unmarshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = it
+	}
+
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIErrorHelpers(t *testing.T) {
+	helpers := map[string]func(err error) bool{
+		"IsTooManyRequests":    IsTooManyRequests,
+		"IsChatMigrated":       IsChatMigrated,
+		"IsBotBlocked":         IsBotBlocked,
+		"IsUnauthorized":       IsUnauthorized,
+		"IsConflict":           IsConflict,
+		"IsMessageNotModified": IsMessageNotModified,
+	}
+
+	for _, test := range []struct {
+		name       string
+		err        *APIError
+		match      string
+		retryAfter time.Duration
+		migrateTo  int64
+	}{
+		{
+			name:       "flood control",
+			err:        &APIError{ErrorCode: 429, Description: "Too Many Requests: retry after 5", Parameters: &ResponseParameters{RetryAfter: 5}},
+			match:      "IsTooManyRequests",
+			retryAfter: 5 * time.Second,
+		},
+		{
+			name:      "migrated",
+			err:       &APIError{ErrorCode: 400, Description: "Bad Request: group chat was upgraded to a supergroup chat", Parameters: &ResponseParameters{MigrateToChatId: -1001}},
+			match:     "IsChatMigrated",
+			migrateTo: -1001,
+		},
+		{name: "blocked", err: &APIError{ErrorCode: 403, Description: "Forbidden: bot was blocked by the user"}, match: "IsBotBlocked"},
+		{name: "kicked", err: &APIError{ErrorCode: 403, Description: "Forbidden: bot was kicked from the group chat"}},
+		{name: "unauthorized", err: &APIError{ErrorCode: 401, Description: "Unauthorized"}, match: "IsUnauthorized"},
+		{name: "conflict", err: &APIError{ErrorCode: 409, Description: "Conflict: terminated by other getUpdates request"}, match: "IsConflict"},
+		{name: "not modified", err: &APIError{ErrorCode: 400, Description: "Bad Request: message is not modified: specified new message content is the same"}, match: "IsMessageNotModified"},
+		{name: "bad request", err: &APIError{ErrorCode: 400, Description: "Bad Request: chat not found"}},
+	} {
+		// helpers must see through wrapping
+		wrapped := fmt.Errorf("send report: %w", test.err)
+
+		for name, helper := range helpers {
+			if got := helper(wrapped); got != (name == test.match) {
+				t.Errorf("%s: %s() = %v", test.name, name, got)
+			}
+		}
+
+		var apiErr *APIError
+		if !errors.As(wrapped, &apiErr) || apiErr != test.err {
+			t.Fatalf("%s: errors.As doesn't find the *APIError", test.name)
+		}
+
+		if apiErr.RetryAfter() != test.retryAfter || apiErr.MigrateToChatId() != test.migrateTo {
+			t.Errorf("%s: RetryAfter() = %s, MigrateToChatId() = %d", test.name, apiErr.RetryAfter(), apiErr.MigrateToChatId())
+		}
+
+		if apiErr.Error() != test.err.Description {
+			t.Errorf("%s: Error() = %q", test.name, apiErr.Error())
+		}
+	}
+
+	for name, helper := range helpers {
+		if helper(errors.New("other")) || helper(nil) {
+			t.Errorf("%s matches an error which is not an *APIError", name)
+		}
+	}
+}
+
+func TestDoRequestNotJSON(t *testing.T) {
+	status := http.StatusBadGateway
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(status)
+		w.Write([]byte("<html><body>Bad Gateway</body></html>"))
+	}))
+	defer srv.Close()
+
+	bot := NewBot("123:TEST", WithServer(srv.URL))
+	bot.HTTPClient = srv.Client()
+
+	// e.g. a proxy in front of the api answers with a page
+	_, err := bot.GetMeCtx(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != 502 || apiErr.Description != "Bad Gateway" {
+		t.Errorf("err = %#v, want an *APIError with the status", err)
+	}
+
+	status = http.StatusOK
+	_, err = bot.GetMeCtx(context.Background())
+	if err == nil || errors.As(err, &apiErr) {
+		t.Errorf("err = %v, want the decoding error", err)
+	}
+}
@@ -2,11 +2,13 @@ package telegram
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 )
 
 var (
@@ -14,50 +16,120 @@ var (
 )
 
 type Bot struct {
-	url       string
-	pollError error
+	token   string
+	server  string
+	local   bool
+	test    bool
+	url     string
+	fileURL string
+	pollMu  sync.Mutex
+	poller  *Poller
 
 	HTTPClient    *http.Client
 	JSONMarshal   func(interface{}) ([]byte, error)
 	JSONUnmarshal func([]byte, interface{}) error
-}
+	// RetryPolicy enables automatic retries of failed requests, disabled when nil
+	RetryPolicy *RetryPolicy
+	// Limiter delays requests to stay within rate limits, see NewRateLimiter
+	Limiter Limiter
 
-func NewBot(token string) *Bot {
-	token = strings.TrimPrefix(token, "bot")
+	middlewares []RequestMiddleware
+}
 
-	return &Bot{
-		url:           BaseURL + "bot" + token + "/",
+func NewBot(token string, options ...botOpt) *Bot {
+	b := &Bot{
+		token:         strings.TrimPrefix(token, "bot"),
+		server:        BaseURL,
 		HTTPClient:    http.DefaultClient,
 		JSONMarshal:   json.Marshal,
 		JSONUnmarshal: json.Unmarshal,
 	}
+
+	for _, fn := range options {
+		fn(b)
+	}
+
+	env := ""
+	if b.test {
+		env = "test/"
+	}
+
+	b.url = b.server + "bot" + b.token + "/" + env
+	b.fileURL = b.server + "file/bot" + b.token + "/" + env
+	return b
+}
+
+func (b *Bot) request(ctx context.Context, method string, request interface{}, result interface{}) error {
+	return b.wrap(b.call)(ctx, method, request, result)
+}
+
+func (b *Bot) call(ctx context.Context, method string, request interface{}, result interface{}) error {
+	err := b.attempt(ctx, method, request, result)
+
+	// uploaded readers are consumed by the first attempt
+	if err == nil || b.RetryPolicy == nil || len(collectUploads(request)) > 0 {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		delay, ok := b.RetryPolicy.retryDelay(method, attempt, err)
+		if !ok || sleep(ctx, delay) != nil {
+			return err
+		}
+
+		if err = b.attempt(ctx, method, request, result); err == nil {
+			return nil
+		}
+	}
 }
 
-func (b *Bot) request(method string, request interface{}, result interface{}) error {
-	return b.doRequest(b.HTTPClient.Do, method, request, result)
+func (b *Bot) attempt(ctx context.Context, method string, request interface{}, result interface{}) error {
+	if b.Limiter != nil {
+		if err := b.Limiter.Wait(ctx, method, requestChatID(request)); err != nil {
+			return err
+		}
+	}
+
+	return b.doRequest(ctx, b.HTTPClient.Do, method, request, result)
 }
 
-func (b *Bot) doRequest(do func(req *http.Request) (*http.Response, error), method string, request interface{}, result interface{}) error {
+func (b *Bot) doRequest(ctx context.Context, do func(req *http.Request) (*http.Response, error), method string, request interface{}, result interface{}) error {
 	httpMethod := http.MethodGet
+	contentType := ""
 	var body io.Reader
 
 	if request != nil {
-		data, err := b.JSONMarshal(request)
-		if err != nil {
-			return err
+		if files := collectUploads(request); len(files) > 0 {
+			multipartBody, multipartType, err := b.encodeMultipart(request, files, b.MaxUploadSize())
+			if err != nil {
+				return err
+			}
+
+			body = multipartBody
+			contentType = multipartType
+		} else {
+			data, err := b.JSONMarshal(request)
+			if err != nil {
+				return err
+			}
+
+			body = bytes.NewReader(data)
+			contentType = "application/json"
 		}
 
-		body = bytes.NewReader(data)
 		httpMethod = http.MethodPost
 	}
 
-	req, err := http.NewRequest(httpMethod, b.url+method, body)
+	req, err := http.NewRequestWithContext(ctx, httpMethod, b.url+method, body)
 	if err != nil {
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
 		return err
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	res, err := do(req)
@@ -65,6 +137,7 @@ func (b *Bot) doRequest(do func(req *http.Request) (*http.Response, error), meth
 		return err
 	}
 
+	defer res.Body.Close()
 	data, err := ioutil.ReadAll(res.Body)
 	if err != nil {
 		return err
@@ -73,16 +146,31 @@ func (b *Bot) doRequest(do func(req *http.Request) (*http.Response, error), meth
 	var apiResult ApiResult
 	err = b.JSONUnmarshal(data, &apiResult)
 	if err != nil {
+		if res.StatusCode != http.StatusOK {
+			return &APIError{
+				ErrorCode:   res.StatusCode,
+				Description: http.StatusText(res.StatusCode),
+			}
+		}
 		return err
 	}
 
 	if !apiResult.Ok {
-		return &apiResult
+		return apiResult.toError()
 	}
 
 	return b.JSONUnmarshal(apiResult.Result, result)
 }
 
+// PollError returns the error which stopped the last PollUpdates.
 func (b *Bot) PollError() error {
-	return b.pollError
+	b.pollMu.Lock()
+	poller := b.poller
+	b.pollMu.Unlock()
+
+	if poller == nil {
+		return nil
+	}
+
+	return poller.Err()
 }
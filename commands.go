@@ -0,0 +1,217 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode/utf16"
+)
+
+// Command is a bot command parsed from a bot_command message entity, e.g.
+// "/start@MyBot arg1 arg2".
+type Command struct {
+	// Name of the command without the leading slash
+	Name string
+	// Mention is the bot username the command was addressed to, if any
+	Mention string
+	// RawArgs is the text after the command
+	RawArgs string
+	Args    []string
+	Message *Message
+}
+
+// ParseCommand returns the command the message starts with, or nil if the
+// message is not a command.
+func ParseCommand(message *Message) *Command {
+	if message == nil {
+		return nil
+	}
+
+	text, entities := message.Text, message.Entities
+	if text == "" {
+		text, entities = message.Caption, message.CaptionEntities
+	}
+
+	for _, e := range entities {
+		if e.Type != MessageEntityTypeBotCommand || e.Offset != 0 || e.Length < 1 {
+			continue
+		}
+
+		units := utf16.Encode([]rune(text))
+		if e.Length > len(units) {
+			return nil
+		}
+
+		name := string(utf16.Decode(units[1:e.Length]))
+		rawArgs := strings.TrimSpace(string(utf16.Decode(units[e.Length:])))
+		name, mention, _ := strings.Cut(name, "@")
+
+		return &Command{
+			Name:    name,
+			Mention: mention,
+			RawArgs: rawArgs,
+			Args:    strings.Fields(rawArgs),
+			Message: message,
+		}
+	}
+
+	return nil
+}
+
+// IsCommand matches messages starting with a bot command.
+func IsCommand(update *Update) bool {
+	return ParseCommand(update.Message) != nil
+}
+
+type CommandHandler func(ctx context.Context, command *Command) error
+
+type commandRoute struct {
+	name        string
+	description string
+	handler     CommandHandler
+	scopes      []BotCommandScope
+}
+
+// CommandRouter dispatches commands to handlers and keeps the command list
+// shown by Telegram clients in sync with the registered handlers.
+type CommandRouter struct {
+	bot    *Bot
+	routes []*commandRoute
+	byName map[string]*commandRoute
+
+	mu       sync.Mutex
+	username string
+
+	// NotFound is called for unknown commands addressed to the bot
+	NotFound CommandHandler
+}
+
+func NewCommandRouter(bot *Bot) *CommandRouter {
+	return &CommandRouter{
+		bot:    bot,
+		byName: map[string]*commandRoute{},
+	}
+}
+
+// Command registers a handler for /name. Commands with a description are
+// published by SyncCommands in each of the given scopes, or in the default
+// scope when no scopes are given.
+func (r *CommandRouter) Command(name, description string, handler CommandHandler, scopes ...BotCommandScope) {
+	route := &commandRoute{
+		name:        strings.ToLower(strings.TrimPrefix(name, "/")),
+		description: description,
+		handler:     handler,
+		scopes:      scopes,
+	}
+
+	r.routes = append(r.routes, route)
+	r.byName[route.name] = route
+}
+
+func (r *CommandRouter) botUsername(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.username == "" {
+		me, err := r.bot.GetMeCtx(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		r.username = me.Username
+	}
+
+	return r.username, nil
+}
+
+// HandleMessage runs the handler of the command in message. Commands
+// addressed to other bots (/start@OtherBot) are ignored.
+func (r *CommandRouter) HandleMessage(ctx context.Context, message *Message) error {
+	command := ParseCommand(message)
+	if command == nil {
+		return nil
+	}
+
+	if command.Mention != "" {
+		username, err := r.botUsername(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(command.Mention, username) {
+			return nil
+		}
+	}
+
+	if route := r.byName[strings.ToLower(command.Name)]; route != nil {
+		return route.handler(ctx, command)
+	}
+
+	if r.NotFound != nil {
+		return r.NotFound(ctx, command)
+	}
+
+	return nil
+}
+
+// Register adds the router to the dispatcher as a handler of command messages.
+func (r *CommandRouter) Register(d *Dispatcher) {
+	d.OnMessage(r.HandleMessage, IsCommand)
+}
+
+type commandScope struct {
+	scope    BotCommandScope
+	commands []*BotCommand
+}
+
+// SyncCommands calls SetMyCommands for every scope used by the registered
+// commands.
+func (r *CommandRouter) SyncCommands(ctx context.Context) error {
+	scopes := []*commandScope{}
+	byKey := map[string]*commandScope{}
+
+	add := func(key string, scope BotCommandScope, route *commandRoute) {
+		s := byKey[key]
+		if s == nil {
+			s = &commandScope{scope: scope}
+			byKey[key] = s
+			scopes = append(scopes, s)
+		}
+
+		s.commands = append(s.commands, &BotCommand{
+			Command:     route.name,
+			Description: route.description,
+		})
+	}
+
+	for _, route := range r.routes {
+		if route.description == "" {
+			continue
+		}
+
+		if len(route.scopes) == 0 {
+			add("", nil, route)
+			continue
+		}
+
+		for _, scope := range route.scopes {
+			data, err := r.bot.JSONMarshal(scope)
+			if err != nil {
+				return err
+			}
+
+			add(string(data), scope, route)
+		}
+	}
+
+	for _, s := range scopes {
+		if _, err := r.bot.SetMyCommandsCtx(ctx, &SetMyCommandsRequest{
+			Commands: s.commands,
+			Scope:    s.scope,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
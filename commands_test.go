@@ -0,0 +1,192 @@
+package telegram_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	telegram "github.com/iamdimka/go-telegram"
+	"github.com/iamdimka/go-telegram/telegramtest"
+)
+
+func TestParseCommand(t *testing.T) {
+	command := func(text string, length int) *telegram.Message {
+		return &telegram.Message{
+			Text: text,
+			Entities: []*telegram.MessageEntity{{
+				Type:   telegram.MessageEntityTypeBotCommand,
+				Length: length,
+			}},
+		}
+	}
+
+	tests := []struct {
+		message *telegram.Message
+		want    *telegram.Command
+	}{
+		{command("/start", 6), &telegram.Command{Name: "start", Args: []string{}}},
+		{command("/start@my_bot a  b", 13), &telegram.Command{Name: "start", Mention: "my_bot", RawArgs: "a  b", Args: []string{"a", "b"}}},
+		{command("/ünï x", 4), &telegram.Command{Name: "ünï", RawArgs: "x", Args: []string{"x"}}},
+		{command("/start", 7), nil},
+		{command("/start", 0), nil},
+		{command("/start", -1), nil},
+		{&telegram.Message{Text: "/start"}, nil},
+	}
+
+	for i, test := range tests {
+		got := telegram.ParseCommand(test.message)
+		if got != nil {
+			got.Message = nil
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: ParseCommand(%q) = %+v, want %+v", i, test.message.Text, got, test.want)
+		}
+	}
+}
+
+// commandMessage returns a message with the command entity, like sent by
+// Telegram clients.
+func commandMessage(text string) *telegram.Message {
+	command, _, _ := strings.Cut(text, " ")
+	return &telegram.Message{
+		Chat: &telegram.Chat{Id: 1, Type: telegram.ChatTypePrivate},
+		Text: text,
+		Entities: []*telegram.MessageEntity{{
+			Type:   telegram.MessageEntityTypeBotCommand,
+			Length: len(utf16.Encode([]rune(command))),
+		}},
+	}
+}
+
+func TestCommandRouter(t *testing.T) {
+	srv := telegramtest.NewServer()
+	defer srv.Close()
+
+	var handled []string
+	record := func(prefix string) telegram.CommandHandler {
+		return func(ctx context.Context, command *telegram.Command) error {
+			handled = append(handled, prefix+command.Name+" "+command.RawArgs)
+			return nil
+		}
+	}
+
+	router := telegram.NewCommandRouter(srv.Bot())
+	router.Command("/start", "Start", record(""))
+
+	ctx := context.Background()
+	for _, text := range []string{
+		"/start a",
+		"/start@OtherBot b",
+		// the mention and the command are case-insensitive
+		"/start@TEST_BOT c",
+		"/START@test_bot d",
+		"/unknown e",
+		"not a command",
+	} {
+		if err := router.HandleMessage(ctx, commandMessage(text)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router.NotFound = record("not found ")
+	for _, text := range []string{"/unknown f", "/unknown@OtherBot g", "/unknown@test_bot h"} {
+		if err := router.HandleMessage(ctx, commandMessage(text)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{"start a", "start c", "START d", "not found unknown f", "not found unknown h"}
+	if !reflect.DeepEqual(handled, want) {
+		t.Errorf("handled %q, want %q", handled, want)
+	}
+
+	// the username is requested once
+	if calls := srv.Calls("getMe"); len(calls) != 1 {
+		t.Errorf("getMe called %d times, want 1", len(calls))
+	}
+}
+
+func TestCommandRouterGetMeError(t *testing.T) {
+	srv := telegramtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("getMe", func(call *telegramtest.Call) (interface{}, error) {
+		return nil, &telegram.APIError{ErrorCode: 500, Description: "Internal Server Error"}
+	})
+
+	router := telegram.NewCommandRouter(srv.Bot())
+	router.Command("start", "Start", func(ctx context.Context, command *telegram.Command) error {
+		t.Error("a command with an unchecked mention was handled")
+		return nil
+	})
+
+	var apiErr *telegram.APIError
+	if err := router.HandleMessage(context.Background(), commandMessage("/start@test_bot")); !errors.As(err, &apiErr) {
+		t.Errorf("err = %v, want the error of getMe", err)
+	}
+}
+
+func TestSyncCommands(t *testing.T) {
+	srv := telegramtest.NewServer()
+	defer srv.Close()
+
+	handler := func(ctx context.Context, command *telegram.Command) error { return nil }
+	admins := &telegram.BotCommandScopeAllChatAdministrators{Type: "all_chat_administrators"}
+	chat := &telegram.BotCommandScopeChat{Type: "chat", ChatId: 1}
+
+	router := telegram.NewCommandRouter(srv.Bot())
+	router.Command("start", "Start the bot", handler)
+	router.Command("ban", "Ban a user", handler, admins)
+	router.Command("hidden", "", handler, admins)
+	router.Command("help", "Show help", handler)
+	router.Command("stats", "Chat stats", handler, &telegram.BotCommandScopeAllChatAdministrators{Type: "all_chat_administrators"}, chat)
+
+	if err := router.SyncCommands(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// one call per scope, in the order scopes are first used
+	calls := srv.Calls("setMyCommands")
+	want := []struct {
+		scope    string
+		commands string
+	}{
+		{"", "start,help"},
+		{`{"type":"all_chat_administrators"}`, "ban,stats"},
+		{`{"type":"chat","chat_id":1}`, "stats"},
+	}
+
+	if len(calls) != len(want) {
+		t.Fatalf("setMyCommands called %d times, want %d", len(calls), len(want))
+	}
+
+	for i, call := range calls {
+		var commands []*telegram.BotCommand
+		if err := call.Decode("commands", &commands); err != nil {
+			t.Fatal(err)
+		}
+
+		names := []string{}
+		for _, command := range commands {
+			if command.Description == "" {
+				t.Errorf("command %s without a description", command.Command)
+			}
+			names = append(names, command.Command)
+		}
+
+		scope := ""
+		if call.Has("scope") {
+			data, _ := json.Marshal(call.Params["scope"])
+			scope = string(data)
+		}
+
+		if scope != want[i].scope || strings.Join(names, ",") != want[i].commands {
+			t.Errorf("call %d: scope %s, commands %v; want %s, %s", i, scope, names, want[i].scope, want[i].commands)
+		}
+	}
+}
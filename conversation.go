@@ -0,0 +1,152 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// State is a step of a Conversation.
+type State string
+
+// EndState returned by a StateHandler ends the conversation and deletes its
+// session.
+const EndState State = ""
+
+// StateHandler handles an update in its state and returns the next state. It
+// may modify session.Data, the session is saved after the handler returns.
+type StateHandler func(ctx context.Context, update *Update, session *Session) (State, error)
+
+// Conversation is a finite-state machine driving multi-step flows, with a
+// state per user in every chat. Updates of users without an active session
+// are passed on, so a conversation is usually started by a command handler
+// calling Start.
+//
+// Updates of one user in a chat must be handled one by one, e.g. by
+// Dispatcher.Run or a WorkerPool.
+type Conversation struct {
+	store  SessionStore
+	states map[State]StateHandler
+
+	// Timeout ends sessions not updated for the duration, zero means never
+	Timeout time.Duration
+	// OnTimeout is called when an update arrives for an expired session,
+	// before the update is passed on
+	OnTimeout func(ctx context.Context, key SessionKey, session *Session) error
+}
+
+func NewConversation(store SessionStore) *Conversation {
+	return &Conversation{
+		store:  store,
+		states: map[State]StateHandler{},
+	}
+}
+
+// State registers the handler of a state.
+func (c *Conversation) State(state State, handler StateHandler) {
+	c.states[state] = handler
+}
+
+// Start begins the conversation for key in state, replacing any active
+// session.
+func (c *Conversation) Start(ctx context.Context, key SessionKey, state State, data map[string]string) error {
+	if _, ok := c.states[state]; !ok {
+		return fmt.Errorf("telegram: unknown conversation state %q", state)
+	}
+
+	return c.store.SaveSession(ctx, key, &Session{
+		State:     state,
+		Data:      data,
+		UpdatedAt: time.Now(),
+	})
+}
+
+// End deletes the session of key.
+func (c *Conversation) End(ctx context.Context, key SessionKey) error {
+	return c.store.DeleteSession(ctx, key)
+}
+
+// Session returns the active session of key, or nil.
+func (c *Conversation) Session(ctx context.Context, key SessionKey) (*Session, error) {
+	session, err := c.store.GetSession(ctx, key)
+	if err != nil || session == nil {
+		return nil, err
+	}
+
+	if c.expired(session) {
+		return nil, nil
+	}
+
+	return session, nil
+}
+
+func (c *Conversation) expired(session *Session) bool {
+	return c.Timeout > 0 && time.Since(session.UpdatedAt) > c.Timeout
+}
+
+// Middleware passes updates of active sessions to the handler of their state
+// and all other updates to next.
+func (c *Conversation) Middleware() UpdateMiddleware {
+	return func(next UpdateHandler) UpdateHandler {
+		return func(ctx context.Context, update *Update) error {
+			handled, err := c.handle(ctx, update)
+			if handled || err != nil {
+				return err
+			}
+
+			return next(ctx, update)
+		}
+	}
+}
+
+// Register makes the dispatcher pass updates of active sessions to the
+// conversation before routing them, see Dispatcher.Use.
+func (c *Conversation) Register(d *Dispatcher) {
+	d.Use(c.Middleware())
+}
+
+func (c *Conversation) handle(ctx context.Context, update *Update) (bool, error) {
+	key, ok := SessionKeyOf(update)
+	if !ok {
+		return false, nil
+	}
+
+	session, err := c.store.GetSession(ctx, key)
+	if err != nil || session == nil {
+		return false, err
+	}
+
+	if c.expired(session) {
+		if err := c.store.DeleteSession(ctx, key); err != nil {
+			return false, err
+		}
+
+		if c.OnTimeout != nil {
+			return false, c.OnTimeout(ctx, key, session)
+		}
+
+		return false, nil
+	}
+
+	handler, ok := c.states[session.State]
+	if !ok {
+		return true, fmt.Errorf("telegram: unknown conversation state %q", session.State)
+	}
+
+	next, err := handler(ctx, update, session)
+	if err != nil {
+		return true, err
+	}
+
+	if next == EndState {
+		return true, c.store.DeleteSession(ctx, key)
+	}
+
+	if _, ok := c.states[next]; !ok {
+		return true, fmt.Errorf("telegram: unknown conversation state %q", next)
+	}
+
+	session.State = next
+	session.UpdatedAt = time.Now()
+	return true, c.store.SaveSession(ctx, key, session)
+}
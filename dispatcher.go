@@ -0,0 +1,228 @@
+package telegram
+
+import "context"
+
+type UpdateHandler func(ctx context.Context, update *Update) error
+
+type Predicate func(update *Update) bool
+
+type route struct {
+	predicates []Predicate
+	handler    UpdateHandler
+}
+
+// Dispatcher routes updates to the first registered handler whose predicates
+// all match. It consumes any update source: the channel returned by
+// PollUpdates or by Webhook.Updates.
+type Dispatcher struct {
+	routes      []route
+	middlewares []UpdateMiddleware
+
+	// NotHandled is called for updates not matched by any handler
+	NotHandled UpdateHandler
+	// OnError is called with errors returned by handlers in Run. When it is nil
+	// Run stops and returns the first error
+	OnError func(update *Update, err error)
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+func (d *Dispatcher) Handle(handler UpdateHandler, predicates ...Predicate) {
+	d.routes = append(d.routes, route{
+		predicates: predicates,
+		handler:    handler,
+	})
+}
+
+func on[T any](d *Dispatcher, get func(update *Update) *T, handler func(ctx context.Context, it *T) error, predicates []Predicate) {
+	predicates = append([]Predicate{func(update *Update) bool {
+		return get(update) != nil
+	}}, predicates...)
+
+	d.Handle(func(ctx context.Context, update *Update) error {
+		return handler(ctx, get(update))
+	}, predicates...)
+}
+
+func (d *Dispatcher) OnMessage(handler func(ctx context.Context, message *Message) error, predicates ...Predicate) {
+	on(d, func(u *Update) *Message { return u.Message }, handler, predicates)
+}
+
+func (d *Dispatcher) OnEditedMessage(handler func(ctx context.Context, message *Message) error, predicates ...Predicate) {
+	on(d, func(u *Update) *Message { return u.EditedMessage }, handler, predicates)
+}
+
+func (d *Dispatcher) OnChannelPost(handler func(ctx context.Context, message *Message) error, predicates ...Predicate) {
+	on(d, func(u *Update) *Message { return u.ChannelPost }, handler, predicates)
+}
+
+func (d *Dispatcher) OnEditedChannelPost(handler func(ctx context.Context, message *Message) error, predicates ...Predicate) {
+	on(d, func(u *Update) *Message { return u.EditedChannelPost }, handler, predicates)
+}
+
+func (d *Dispatcher) OnInlineQuery(handler func(ctx context.Context, query *InlineQuery) error, predicates ...Predicate) {
+	on(d, func(u *Update) *InlineQuery { return u.InlineQuery }, handler, predicates)
+}
+
+func (d *Dispatcher) OnChosenInlineResult(handler func(ctx context.Context, result *ChosenInlineResult) error, predicates ...Predicate) {
+	on(d, func(u *Update) *ChosenInlineResult { return u.ChosenInlineResult }, handler, predicates)
+}
+
+func (d *Dispatcher) OnCallbackQuery(handler func(ctx context.Context, query *CallbackQuery) error, predicates ...Predicate) {
+	on(d, func(u *Update) *CallbackQuery { return u.CallbackQuery }, handler, predicates)
+}
+
+func (d *Dispatcher) OnShippingQuery(handler func(ctx context.Context, query *ShippingQuery) error, predicates ...Predicate) {
+	on(d, func(u *Update) *ShippingQuery { return u.ShippingQuery }, handler, predicates)
+}
+
+func (d *Dispatcher) OnPreCheckoutQuery(handler func(ctx context.Context, query *PreCheckoutQuery) error, predicates ...Predicate) {
+	on(d, func(u *Update) *PreCheckoutQuery { return u.PreCheckoutQuery }, handler, predicates)
+}
+
+func (d *Dispatcher) OnPoll(handler func(ctx context.Context, poll *Poll) error, predicates ...Predicate) {
+	on(d, func(u *Update) *Poll { return u.Poll }, handler, predicates)
+}
+
+func (d *Dispatcher) OnPollAnswer(handler func(ctx context.Context, answer *PollAnswer) error, predicates ...Predicate) {
+	on(d, func(u *Update) *PollAnswer { return u.PollAnswer }, handler, predicates)
+}
+
+func (d *Dispatcher) OnMyChatMember(handler func(ctx context.Context, member *ChatMemberUpdated) error, predicates ...Predicate) {
+	on(d, func(u *Update) *ChatMemberUpdated { return u.MyChatMember }, handler, predicates)
+}
+
+func (d *Dispatcher) OnChatMember(handler func(ctx context.Context, member *ChatMemberUpdated) error, predicates ...Predicate) {
+	on(d, func(u *Update) *ChatMemberUpdated { return u.ChatMember }, handler, predicates)
+}
+
+func (d *Dispatcher) OnChatJoinRequest(handler func(ctx context.Context, request *ChatJoinRequest) error, predicates ...Predicate) {
+	on(d, func(u *Update) *ChatJoinRequest { return u.ChatJoinRequest }, handler, predicates)
+}
+
+// Use adds middlewares around all handlers of the dispatcher, including
+// NotHandled. The first middleware is the outermost.
+func (d *Dispatcher) Use(middlewares ...UpdateMiddleware) {
+	d.middlewares = append(d.middlewares, middlewares...)
+}
+
+// HandleUpdate passes update to the first matching handler.
+func (d *Dispatcher) HandleUpdate(ctx context.Context, update *Update) error {
+	return Chain(d.route, d.middlewares...)(ctx, update)
+}
+
+func (d *Dispatcher) route(ctx context.Context, update *Update) error {
+	for _, r := range d.routes {
+		if matchAll(update, r.predicates) {
+			return r.handler(ctx, update)
+		}
+	}
+
+	if d.NotHandled != nil {
+		return d.NotHandled(ctx, update)
+	}
+
+	return nil
+}
+
+// Run handles updates one by one until the channel is closed or ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, updates <-chan *Update) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+
+			if err := d.HandleUpdate(ctx, update); err != nil {
+				if d.OnError == nil {
+					return err
+				}
+
+				d.OnError(update, err)
+			}
+		}
+	}
+}
+
+func matchAll(update *Update, predicates []Predicate) bool {
+	for _, p := range predicates {
+		if !p(update) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func And(predicates ...Predicate) Predicate {
+	return func(update *Update) bool {
+		return matchAll(update, predicates)
+	}
+}
+
+func Or(predicates ...Predicate) Predicate {
+	return func(update *Update) bool {
+		for _, p := range predicates {
+			if p(update) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func Not(predicate Predicate) Predicate {
+	return func(update *Update) bool {
+		return !predicate(update)
+	}
+}
+
+// InChat matches updates from chats of the given types.
+func InChat(types ...ChatType) Predicate {
+	return func(update *Update) bool {
+		chat := update.EffectiveChat()
+		if chat == nil {
+			return false
+		}
+
+		for _, t := range types {
+			if chat.Type == t {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// UserID matches updates caused by one of the users.
+func UserID(ids ...int64) Predicate {
+	return func(update *Update) bool {
+		user := update.EffectiveUser()
+		if user == nil {
+			return false
+		}
+
+		for _, id := range ids {
+			if user.Id == id {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// CallbackData matches callback queries by exact data.
+func CallbackData(data string) Predicate {
+	return func(update *Update) bool {
+		return update.CallbackQuery != nil && update.CallbackQuery.Data == data
+	}
+}
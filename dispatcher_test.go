@@ -0,0 +1,198 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func chatMessage(chatType ChatType, userID int64, text string) *Message {
+	return &Message{
+		Chat: &Chat{Id: -1, Type: chatType},
+		From: &User{Id: userID},
+		Text: text,
+	}
+}
+
+func callback(userID int64, data string) *Update {
+	return &Update{CallbackQuery: &CallbackQuery{
+		From:    &User{Id: userID},
+		Message: chatMessage(ChatTypePrivate, 0, "buttons"),
+		Data:    data,
+	}}
+}
+
+// routed returns a message handler recording the name of its route.
+func routed(handled *[]string, name string) func(ctx context.Context, message *Message) error {
+	return func(ctx context.Context, message *Message) error {
+		*handled = append(*handled, name)
+		return nil
+	}
+}
+
+func TestDispatcherRoute(t *testing.T) {
+	var handled []string
+	d := NewDispatcher()
+
+	d.OnCallbackQuery(func(ctx context.Context, query *CallbackQuery) error {
+		handled = append(handled, "callback "+query.Data)
+		return nil
+	}, CallbackData("a"))
+	d.OnMessage(routed(&handled, "group"), InChat(ChatTypeGroup, ChatTypeSupergroup))
+	d.OnMessage(routed(&handled, "message"))
+	d.OnMessage(routed(&handled, "unreachable"))
+	d.OnEditedMessage(routed(&handled, "edited"), UserID(1))
+
+	updates := []*Update{
+		{Message: chatMessage(ChatTypePrivate, 1, "hi")},
+		{Message: chatMessage(ChatTypeSupergroup, 1, "hi")},
+		callback(1, "a"),
+		// the callback has a message, but only u.Message is matched by OnMessage
+		callback(1, "b"),
+		{EditedMessage: chatMessage(ChatTypePrivate, 1, "edited")},
+		{EditedMessage: chatMessage(ChatTypePrivate, 2, "edited")},
+		{UpdateId: 1},
+	}
+
+	ctx := context.Background()
+	for _, update := range updates {
+		if err := d.HandleUpdate(ctx, update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// without NotHandled unmatched updates are dropped
+	if err := d.HandleUpdate(ctx, &Update{UpdateId: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	d.NotHandled = func(ctx context.Context, update *Update) error {
+		handled = append(handled, "not handled")
+		return nil
+	}
+	if err := d.HandleUpdate(ctx, &Update{UpdateId: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"message", "group", "callback a", "edited", "not handled"}
+	if len(handled) != len(want) {
+		t.Fatalf("handled by %q, want %q", handled, want)
+	}
+
+	for i := range want {
+		if handled[i] != want[i] {
+			t.Errorf("update %d handled by %q, want %q", i, handled[i], want[i])
+		}
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	yes := func(update *Update) bool { return true }
+	no := func(update *Update) bool { return false }
+
+	private := &Update{Message: chatMessage(ChatTypePrivate, 1, "hi")}
+	channel := &Update{ChannelPost: &Message{Chat: &Chat{Type: ChatTypeChannel}}}
+	empty := &Update{}
+
+	for _, test := range []struct {
+		name      string
+		predicate Predicate
+		update    *Update
+		want      bool
+	}{
+		{"And", And(yes, yes), empty, true},
+		{"And false", And(yes, no), empty, false},
+		{"And empty", And(), empty, true},
+		{"Or", Or(no, yes), empty, true},
+		{"Or false", Or(no, no), empty, false},
+		{"Or empty", Or(), empty, false},
+		{"Not", Not(no), empty, true},
+		{"Not false", Not(yes), empty, false},
+		{"InChat", InChat(ChatTypeGroup, ChatTypePrivate), private, true},
+		{"InChat other type", InChat(ChatTypeGroup), private, false},
+		{"InChat no chat", InChat(ChatTypePrivate), empty, false},
+		{"UserID", UserID(2, 1), private, true},
+		{"UserID other user", UserID(2), private, false},
+		{"UserID no user", UserID(0), channel, false},
+		{"CallbackData", CallbackData("a"), callback(1, "a"), true},
+		{"CallbackData other data", CallbackData("a"), callback(1, "ab"), false},
+		{"CallbackData no callback", CallbackData(""), private, false},
+		{"combined", And(InChat(ChatTypePrivate), Not(UserID(2))), private, true},
+	} {
+		if got := test.predicate(test.update); got != test.want {
+			t.Errorf("%s = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func feed(updates ...*Update) <-chan *Update {
+	ch := make(chan *Update, len(updates))
+	for _, update := range updates {
+		ch <- update
+	}
+	close(ch)
+	return ch
+}
+
+func TestDispatcherRunErrors(t *testing.T) {
+	failure := errors.New("failure")
+	var handled []int64
+	d := NewDispatcher()
+	d.Handle(func(ctx context.Context, update *Update) error {
+		handled = append(handled, update.UpdateId)
+		if update.UpdateId%2 == 1 {
+			return failure
+		}
+		return nil
+	})
+
+	// without OnError the first error stops Run
+	if err := d.Run(context.Background(), feed(&Update{UpdateId: 0}, &Update{UpdateId: 1}, &Update{UpdateId: 2})); err != failure {
+		t.Errorf("Run() = %v, want %v", err, failure)
+	}
+
+	if len(handled) != 2 {
+		t.Errorf("handled %v, want the updates up to the error", handled)
+	}
+
+	handled = nil
+	var failed []int64
+	d.OnError = func(update *Update, err error) {
+		if err != failure {
+			t.Errorf("OnError got %v", err)
+		}
+		failed = append(failed, update.UpdateId)
+	}
+
+	if err := d.Run(context.Background(), feed(&Update{UpdateId: 0}, &Update{UpdateId: 1}, &Update{UpdateId: 2}, &Update{UpdateId: 3})); err != nil {
+		t.Errorf("Run() = %v, want nil when the channel is closed", err)
+	}
+
+	if len(handled) != 4 || len(failed) != 2 || failed[0] != 1 || failed[1] != 3 {
+		t.Errorf("handled %v, failed %v", handled, failed)
+	}
+}
+
+func TestDispatcherRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan *Update)
+	d := NewDispatcher()
+	d.Handle(func(ctx context.Context, update *Update) error {
+		cancel()
+		return nil
+	})
+
+	done := make(chan error)
+	go func() { done <- d.Run(ctx, updates) }()
+
+	updates <- &Update{}
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run doesn't stop when ctx is done")
+	}
+}
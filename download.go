@@ -0,0 +1,208 @@
+package telegram
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+var (
+	ErrFileTooLarge = errors.New("telegram: file is too large")
+	ErrNoFilePath   = errors.New("telegram: file has no file path to download")
+)
+
+// SavedFile describes a file written by SaveFile.
+type SavedFile struct {
+	File *File
+	Path string
+	Size int64
+	// SHA256 is the hex encoded checksum of the written bytes
+	SHA256 string
+}
+
+// FileDownloadURL returns the URL to download a file by File.FilePath. The URL
+// contains the bot token.
+func (b *Bot) FileDownloadURL(filePath string) string {
+	return b.fileURL + filePath
+}
+
+// DownloadFile gets the file path with getFile and returns the contents of the
+// file. The caller must close the returned reader.
+func (b *Bot) DownloadFile(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	file, err := b.GetFileCtx(ctx, &GetFileRequest{FileId: fileID})
+	if err != nil {
+		return nil, err
+	}
+
+	body, _, err := b.openFile(ctx, file.FilePath, 0)
+	return body, err
+}
+
+// SaveFile downloads a file to path, failing with ErrFileTooLarge if the file
+// is bigger than maxSize; zero means Bot.MaxDownloadSize and a negative size
+// means no limit. The file is written to path+".part" first and renamed when
+// complete; if a download is interrupted the partial file is kept, and the
+// next SaveFile with the same path resumes it with a Range request.
+func (b *Bot) SaveFile(ctx context.Context, fileID, path string, maxSize int64) (*SavedFile, error) {
+	file, err := b.GetFileCtx(ctx, &GetFileRequest{FileId: fileID})
+	if err != nil {
+		return nil, err
+	}
+
+	if maxSize == 0 {
+		maxSize = b.MaxDownloadSize()
+	}
+
+	if maxSize > 0 && int64(file.FileSize) > maxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	partPath := path + ".part"
+	part, err := os.OpenFile(partPath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	size, hash, err := b.resume(ctx, part, file.FilePath, maxSize)
+	if closeErr := part.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		if errors.Is(err, ErrFileTooLarge) {
+			os.Remove(partPath)
+		}
+		return nil, err
+	}
+
+	if err := os.Rename(partPath, path); err != nil {
+		return nil, err
+	}
+
+	return &SavedFile{
+		File:   file,
+		Path:   path,
+		Size:   size,
+		SHA256: hash,
+	}, nil
+}
+
+// resume appends the rest of the file to part, returning the total size and
+// checksum.
+func (b *Bot) resume(ctx context.Context, part *os.File, filePath string, maxSize int64) (int64, string, error) {
+	hash := sha256.New()
+	offset, err := io.Copy(hash, part)
+	if err != nil {
+		return 0, "", err
+	}
+
+	body, start, err := b.openFile(ctx, filePath, offset)
+	if err != nil {
+		return 0, "", err
+	}
+	defer body.Close()
+
+	// the server ignored the range, start over
+	if start != offset {
+		if err := part.Truncate(0); err != nil {
+			return 0, "", err
+		}
+
+		if _, err := part.Seek(0, io.SeekStart); err != nil {
+			return 0, "", err
+		}
+
+		hash.Reset()
+		offset = 0
+	}
+
+	src := io.Reader(body)
+	if maxSize > 0 {
+		src = io.LimitReader(src, maxSize-offset+1)
+	}
+
+	n, err := io.Copy(io.MultiWriter(part, hash), src)
+	size := offset + n
+	if err != nil {
+		return 0, "", err
+	}
+
+	if maxSize > 0 && size > maxSize {
+		return 0, "", ErrFileTooLarge
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// openFile returns the contents of the file from offset, or from the start if
+// the server doesn't support ranges; start is the offset the contents begin at.
+// A local server's files are read from disk.
+func (b *Bot) openFile(ctx context.Context, filePath string, offset int64) (body io.ReadCloser, start int64, err error) {
+	if filePath == "" {
+		return nil, 0, ErrNoFilePath
+	}
+
+	if b.local && filepath.IsAbs(filePath) {
+		return openLocalFile(filePath, offset)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.FileDownloadURL(filePath), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	res, err := b.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, 0, nil
+	case http.StatusPartialContent:
+		return res.Body, offset, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the partial file is already complete
+		if offset > 0 {
+			res.Body.Close()
+			return http.NoBody, offset, nil
+		}
+	}
+
+	defer res.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(res.Body, 1<<16))
+
+	var apiResult ApiResult
+	if b.JSONUnmarshal(data, &apiResult) == nil && apiResult.ErrorCode != 0 {
+		return nil, 0, apiResult.toError()
+	}
+
+	return nil, 0, &APIError{
+		ErrorCode:   res.StatusCode,
+		Description: http.StatusText(res.StatusCode),
+	}
+}
+
+func openLocalFile(filePath string, offset int64) (io.ReadCloser, int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+
+	return file, offset, nil
+}
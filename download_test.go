@@ -0,0 +1,343 @@
+package telegram_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	telegram "github.com/iamdimka/go-telegram"
+	"github.com/iamdimka/go-telegram/telegramtest"
+)
+
+var fileData = []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+
+// fileTransport records the Range headers of file downloads; it can drop them,
+// like a server not supporting ranges, and break downloads after failAfter
+// bytes.
+type fileTransport struct {
+	http.RoundTripper
+
+	mu          sync.Mutex
+	ranges      []string
+	ignoreRange bool
+	failAfter   int
+}
+
+func (t *fileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.Contains(req.URL.Path, "/file/") {
+		return t.RoundTripper.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	t.ranges = append(t.ranges, req.Header.Get("Range"))
+	ignoreRange, failAfter := t.ignoreRange, t.failAfter
+	t.mu.Unlock()
+
+	if ignoreRange {
+		req = req.Clone(req.Context())
+		req.Header.Del("Range")
+	}
+
+	res, err := t.RoundTripper.RoundTrip(req)
+	if err != nil || failAfter == 0 {
+		return res, err
+	}
+
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(io.LimitReader(res.Body, int64(failAfter)), &failingReader{}), res.Body}
+	return res, nil
+}
+
+func (t *fileTransport) Ranges() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string{}, t.ranges...)
+}
+
+type failingReader struct{}
+
+func (*failingReader) Read(p []byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+func newFileBot(t *testing.T) (*telegramtest.Server, *telegram.Bot, *fileTransport) {
+	t.Helper()
+
+	srv := telegramtest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.AddFile("file-1", "documents/file.txt", fileData)
+
+	bot := srv.Bot()
+	transport := &fileTransport{RoundTripper: bot.HTTPClient.Transport}
+	bot.HTTPClient = &http.Client{Transport: transport}
+	return srv, bot, transport
+}
+
+func checkSaved(t *testing.T, saved *telegram.SavedFile, path string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, fileData) {
+		t.Errorf("saved %q, want %q", data, fileData)
+	}
+
+	sum := sha256.Sum256(fileData)
+	if saved.Path != path || saved.Size != int64(len(fileData)) || saved.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("SaveFile() = %+v", saved)
+	}
+
+	if _, err := os.Stat(path + ".part"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("the partial file is kept: %v", err)
+	}
+}
+
+func TestSaveFile(t *testing.T) {
+	_, bot, transport := newFileBot(t)
+	path := filepath.Join(t.TempDir(), "file.txt")
+
+	saved, err := bot.SaveFile(context.Background(), "file-1", path, int64(len(fileData)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkSaved(t, saved, path)
+	if saved.File.FileId != "file-1" {
+		t.Errorf("File = %+v", saved.File)
+	}
+
+	if ranges := transport.Ranges(); len(ranges) != 1 || ranges[0] != "" {
+		t.Errorf("Range headers %q, want one download without a range", ranges)
+	}
+}
+
+func TestSaveFileResume(t *testing.T) {
+	ctx := context.Background()
+	_, bot, transport := newFileBot(t)
+	path := filepath.Join(t.TempDir(), "file.txt")
+
+	// the interrupted download is kept in the partial file
+	transport.failAfter = 10
+	if _, err := bot.SaveFile(ctx, "file-1", path, 0); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("err = %v, want an interrupted download", err)
+	}
+
+	if data, err := os.ReadFile(path + ".part"); err != nil || !bytes.Equal(data, fileData[:10]) {
+		t.Fatalf("partial file %q, %v", data, err)
+	}
+
+	// the checksum covers the bytes of the previous attempt too
+	transport.failAfter = 0
+	saved, err := bot.SaveFile(ctx, "file-1", path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkSaved(t, saved, path)
+	if ranges := transport.Ranges(); len(ranges) != 2 || ranges[1] != "bytes=10-" {
+		t.Errorf("Range headers %q, want bytes=10- for the second download", ranges)
+	}
+}
+
+func TestSaveFileRangeIgnored(t *testing.T) {
+	_, bot, transport := newFileBot(t)
+	transport.ignoreRange = true
+	path := filepath.Join(t.TempDir(), "file.txt")
+
+	// the contents of the partial file are replaced when the server sends
+	// the whole file
+	if err := os.WriteFile(path+".part", []byte("stale"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := bot.SaveFile(context.Background(), "file-1", path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkSaved(t, saved, path)
+	if ranges := transport.Ranges(); len(ranges) != 1 || ranges[0] != "bytes=5-" {
+		t.Errorf("Range headers %q, want bytes=5-", ranges)
+	}
+}
+
+func TestSaveFileCompletePart(t *testing.T) {
+	_, bot, transport := newFileBot(t)
+	path := filepath.Join(t.TempDir(), "file.txt")
+
+	// a download interrupted before the rename is answered with 416
+	if err := os.WriteFile(path+".part", fileData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := bot.SaveFile(context.Background(), "file-1", path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkSaved(t, saved, path)
+	if ranges := transport.Ranges(); len(ranges) != 1 || ranges[0] != "bytes=36-" {
+		t.Errorf("Range headers %q, want bytes=36-", ranges)
+	}
+}
+
+func TestSaveFileTooLarge(t *testing.T) {
+	ctx := context.Background()
+	srv, bot, transport := newFileBot(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+
+	// FileSize of getFile is checked before downloading
+	if _, err := bot.SaveFile(ctx, "file-1", path, 10); !errors.Is(err, telegram.ErrFileTooLarge) {
+		t.Errorf("err = %v, want ErrFileTooLarge", err)
+	}
+
+	if ranges := transport.Ranges(); len(ranges) != 0 {
+		t.Errorf("the file was downloaded %d times", len(ranges))
+	}
+
+	// without FileSize the limit is checked while downloading
+	srv.Handle("getFile", func(call *telegramtest.Call) (interface{}, error) {
+		return &telegram.File{FileId: call.String("file_id"), FilePath: "documents/file.txt"}, nil
+	})
+
+	if _, err := bot.SaveFile(ctx, "file-1", path, 10); !errors.Is(err, telegram.ErrFileTooLarge) {
+		t.Errorf("err = %v, want ErrFileTooLarge", err)
+	}
+
+	if ranges := transport.Ranges(); len(ranges) != 1 {
+		t.Errorf("the file was downloaded %d times, want once", len(ranges))
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("files are left after ErrFileTooLarge: %v", entries)
+	}
+
+	saved, err := bot.SaveFile(ctx, "file-1", path, int64(len(fileData)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkSaved(t, saved, path)
+}
+
+func TestSaveFileErrors(t *testing.T) {
+	ctx := context.Background()
+	srv, bot, _ := newFileBot(t)
+	path := filepath.Join(t.TempDir(), "file.txt")
+
+	filePath := ""
+	srv.Handle("getFile", func(call *telegramtest.Call) (interface{}, error) {
+		return &telegram.File{FileId: call.String("file_id"), FilePath: filePath}, nil
+	})
+
+	if _, err := bot.SaveFile(ctx, "file-1", path, 0); !errors.Is(err, telegram.ErrNoFilePath) {
+		t.Errorf("err = %v, want ErrNoFilePath", err)
+	}
+
+	filePath = "documents/missing.txt"
+	var apiErr *telegram.APIError
+	if _, err := bot.SaveFile(ctx, "file-1", path, 0); !errors.As(err, &apiErr) || apiErr.ErrorCode != http.StatusNotFound {
+		t.Errorf("err = %v, want 404", err)
+	}
+
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("a failed download created %s: %v", path, err)
+	}
+}
+
+func TestSaveFileDefaultLimit(t *testing.T) {
+	ctx := context.Background()
+	srv, bot, transport := newFileBot(t)
+	srv.Handle("getFile", func(call *telegramtest.Call) (interface{}, error) {
+		return &telegram.File{FileId: call.String("file_id"), FileSize: telegram.MaxDownloadSize + 1, FilePath: "documents/file.txt"}, nil
+	})
+
+	// zero is the limit of the cloud server, a negative size is no limit
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if _, err := bot.SaveFile(ctx, "file-1", path, 0); !errors.Is(err, telegram.ErrFileTooLarge) {
+		t.Errorf("err = %v, want ErrFileTooLarge", err)
+	}
+
+	if ranges := transport.Ranges(); len(ranges) != 0 {
+		t.Errorf("the file was downloaded %d times", len(ranges))
+	}
+
+	saved, err := bot.SaveFile(ctx, "file-1", path, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkSaved(t, saved, path)
+}
+
+// TestLocalServerFiles reads files of a local server from disk, by the
+// absolute path returned by getFile.
+func TestLocalServerFiles(t *testing.T) {
+	ctx := context.Background()
+	srv, cloud, transport := newFileBot(t)
+	dir := t.TempDir()
+	local := filepath.Join(dir, "server", "documents", "file.txt")
+	if err := os.MkdirAll(filepath.Dir(local), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(local, fileData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Handle("getFile", func(call *telegramtest.Call) (interface{}, error) {
+		// files of a local server have no size limit
+		return &telegram.File{FileId: call.String("file_id"), FileSize: telegram.MaxDownloadSize + 1, FilePath: local}, nil
+	})
+
+	bot := telegram.NewBot(srv.Token, telegram.WithLocalServer(srv.URL()))
+	bot.HTTPClient = cloud.HTTPClient
+
+	body, err := bot.DownloadFile(ctx, "file-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || !bytes.Equal(data, fileData) {
+		t.Errorf("downloaded %q, %v", data, err)
+	}
+
+	// a partial file is resumed from the offset on disk
+	path := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(path+".part", fileData[:10], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := bot.SaveFile(ctx, "file-1", path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkSaved(t, saved, path)
+	if ranges := transport.Ranges(); len(ranges) != 0 {
+		t.Errorf("%d files were downloaded over HTTP", len(ranges))
+	}
+
+	// the same path on a bot of the cloud server is a path on the server
+	if _, err := cloud.DownloadFile(ctx, "file-1"); err == nil {
+		t.Error("a cloud bot read a local file")
+	}
+}
@@ -0,0 +1,68 @@
+package telegram
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+)
+
+var ErrInputFileNotAttached = errors.New("telegram: InputFile with a reader can only be sent as multipart/form-data")
+
+// InputFile represents the contents of a file to be uploaded. It is either a
+// file_id of a file that exists on the Telegram servers, an HTTP URL for
+// Telegram to get a file from the Internet, or a reader that is streamed with
+// multipart/form-data.
+type InputFile struct {
+	FileID string
+	URL    string
+
+	// Name is the filename reported to Telegram for uploads
+	Name   string
+	Reader io.Reader
+
+	attach string
+}
+
+func FileID(fileID string) *InputFile {
+	return &InputFile{FileID: fileID}
+}
+
+func FileURL(url string) *InputFile {
+	return &InputFile{URL: url}
+}
+
+// LocalFile refers to a file on the disk of a local Bot API server (see
+// WithLocalServer) by a file:// URL, so it isn't uploaded over HTTP.
+func LocalFile(path string) *InputFile {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	return &InputFile{URL: "file://" + filepath.ToSlash(path)}
+}
+
+func FileReader(name string, reader io.Reader) *InputFile {
+	return &InputFile{Name: name, Reader: reader}
+}
+
+func (f *InputFile) IsUpload() bool {
+	return f != nil && f.Reader != nil
+}
+
+func (f *InputFile) MarshalJSON() ([]byte, error) {
+	switch {
+	case f.Reader != nil:
+		if f.attach == "" {
+			return nil, ErrInputFileNotAttached
+		}
+
+		return json.Marshal("attach://" + f.attach)
+
+	case f.FileID != "":
+		return json.Marshal(f.FileID)
+
+	default:
+		return json.Marshal(f.URL)
+	}
+}
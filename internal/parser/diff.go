@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+type change struct {
+	text     string
+	breaking bool
+}
+
+// changelog lists differences between the previously generated API (data/*.json)
+// and the parsed one. Breaking changes are those that can break code compiled
+// against the previous version or requests valid for it.
+type changelog []change
+
+func (c *changelog) add(breaking bool, format string, args ...interface{}) {
+	*c = append(*c, change{text: fmt.Sprintf(format, args...), breaking: breaking})
+}
+
+func (c changelog) breaking() int {
+	n := 0
+	for _, it := range c {
+		if it.breaking {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (c changelog) write(w io.Writer) {
+	if len(c) == 0 {
+		fmt.Fprintln(w, "No API changes")
+		return
+	}
+
+	for _, breaking := range []bool{true, false} {
+		title := "Changes:"
+		if breaking {
+			title = "Breaking changes:"
+		}
+
+		printed := false
+		for _, it := range c {
+			if it.breaking != breaking {
+				continue
+			}
+
+			if !printed {
+				fmt.Fprintln(w, title)
+				printed = true
+			}
+			fmt.Fprintf(w, "- %s\n", it.text)
+		}
+	}
+}
+
+// loadPrevious reads a previously written data file, a missing file is not an
+// error and leaves v untouched.
+func loadPrevious(filename string, v interface{}) error {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func diffAPI(oldModels, models []*APIStruct, oldRequests, requests []*APIMethod) changelog {
+	c := changelog{}
+	diffStructs(&c, oldModels, models)
+	diffMethods(&c, oldRequests, requests)
+	return c
+}
+
+func diffStructs(c *changelog, old, parsed []*APIStruct) {
+	previous := make(map[string]*APIStruct, len(old))
+	for _, s := range old {
+		previous[s.Name] = s
+	}
+
+	current := make(map[string]bool, len(parsed))
+	for _, s := range parsed {
+		current[s.Name] = true
+
+		prev := previous[s.Name]
+		if prev == nil {
+			c.add(false, "type %s added", s.Name)
+			continue
+		}
+
+		fields := make(map[string]APIField, len(prev.Fields))
+		for _, f := range prev.Fields {
+			fields[f.Field] = f
+		}
+
+		seen := make(map[string]bool, len(s.Fields))
+		for _, f := range s.Fields {
+			seen[f.Field] = true
+
+			pf, ok := fields[f.Field]
+			switch {
+			case !ok:
+				c.add(false, "field %s.%s %s added", s.Name, f.Field, f.Type)
+			case pf.Type != f.Type:
+				c.add(true, "field %s.%s changed type from %s to %s", s.Name, f.Field, pf.Type, f.Type)
+			case pf.Optional && !f.Optional:
+				c.add(false, "field %s.%s became required", s.Name, f.Field)
+			case !pf.Optional && f.Optional:
+				c.add(false, "field %s.%s became optional", s.Name, f.Field)
+			}
+		}
+
+		for _, f := range prev.Fields {
+			if !seen[f.Field] {
+				c.add(true, "field %s.%s removed", s.Name, f.Field)
+			}
+		}
+	}
+
+	for _, s := range old {
+		if !current[s.Name] {
+			c.add(true, "type %s removed", s.Name)
+		}
+	}
+}
+
+func diffMethods(c *changelog, old, parsed []*APIMethod) {
+	previous := make(map[string]*APIMethod, len(old))
+	for _, m := range old {
+		previous[m.Name] = m
+	}
+
+	current := make(map[string]bool, len(parsed))
+	for _, m := range parsed {
+		current[m.Name] = true
+
+		prev := previous[m.Name]
+		if prev == nil {
+			c.add(false, "method %s added", m.Name)
+			continue
+		}
+
+		if prev.Return != m.Return {
+			c.add(true, "method %s changed return type from %s to %s", m.Name, prev.Return, m.Return)
+		}
+
+		params := make(map[string]APIParameter, len(prev.Params))
+		for _, p := range prev.Params {
+			params[p.Name] = p
+		}
+
+		seen := make(map[string]bool, len(m.Params))
+		for _, p := range m.Params {
+			seen[p.Name] = true
+
+			pp, ok := params[p.Name]
+			switch {
+			case !ok && p.Required:
+				c.add(true, "method %s got required parameter %s %s", m.Name, p.Name, p.Type)
+			case !ok:
+				c.add(false, "method %s got parameter %s %s", m.Name, p.Name, p.Type)
+			case pp.Type != p.Type:
+				c.add(true, "parameter %s.%s changed type from %s to %s", m.Name, p.Name, pp.Type, p.Type)
+			case !pp.Required && p.Required:
+				c.add(true, "parameter %s.%s became required", m.Name, p.Name)
+			case pp.Required && !p.Required:
+				c.add(false, "parameter %s.%s became optional", m.Name, p.Name)
+			}
+		}
+
+		for _, p := range prev.Params {
+			if !seen[p.Name] {
+				c.add(true, "parameter %s.%s removed", m.Name, p.Name)
+			}
+		}
+	}
+
+	for _, m := range old {
+		if !current[m.Name] {
+			c.add(true, "method %s removed", m.Name)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffAPI(t *testing.T) {
+	oldModels := []*APIStruct{
+		{Name: "User", Fields: []APIField{
+			{Field: "id", Type: "int64"},
+			{Field: "username", Type: "string", Optional: true},
+			{Field: "language", Type: "string", Optional: true},
+		}},
+		{Name: "Chat", Fields: []APIField{
+			{Field: "id", Type: "int64"},
+			{Field: "title", Type: "string"},
+		}},
+		{Name: "Removed"},
+	}
+	models := []*APIStruct{
+		{Name: "User", Fields: []APIField{
+			{Field: "id", Type: "string"},
+			{Field: "username", Type: "string"},
+			{Field: "is_premium", Type: "bool", Optional: true},
+		}},
+		{Name: "Chat", Fields: []APIField{
+			{Field: "id", Type: "int64"},
+			{Field: "title", Type: "string", Optional: true},
+		}},
+		{Name: "Story"},
+	}
+
+	oldRequests := []*APIMethod{
+		{Name: "sendMessage", Return: "*Message", Params: []APIParameter{
+			{Name: "chat_id", Type: "interface{}", Required: true},
+			{Name: "text", Type: "string", Required: true},
+			{Name: "parse_mode", Type: "string"},
+			{Name: "thread_id", Type: "int64"},
+			{Name: "old", Type: "bool"},
+		}},
+		{Name: "getMe", Return: "*User"},
+		{Name: "removedMethod", Return: "bool"},
+	}
+	requests := []*APIMethod{
+		{Name: "sendMessage", Return: "*Message", Params: []APIParameter{
+			{Name: "chat_id", Type: "interface{}", Required: true},
+			{Name: "text", Type: "string"},
+			{Name: "parse_mode", Type: "ParseMode"},
+			{Name: "thread_id", Type: "int64", Required: true},
+			{Name: "reply_parameters", Type: "*ReplyParameters"},
+			{Name: "business_id", Type: "string", Required: true},
+		}},
+		{Name: "getMe", Return: "*Bot"},
+		{Name: "getStory", Return: "*Story"},
+	}
+
+	c := diffAPI(oldModels, models, oldRequests, requests)
+
+	want := []change{
+		{"field User.id changed type from int64 to string", true},
+		// a required field is still decoded into the same Go type
+		{"field User.username became required", false},
+		{"field User.is_premium bool added", false},
+		{"field User.language removed", true},
+		{"field Chat.title became optional", false},
+		{"type Story added", false},
+		{"type Removed removed", true},
+		{"parameter sendMessage.text became optional", false},
+		{"parameter sendMessage.parse_mode changed type from string to ParseMode", true},
+		{"parameter sendMessage.thread_id became required", true},
+		{"method sendMessage got parameter reply_parameters *ReplyParameters", false},
+		{"method sendMessage got required parameter business_id string", true},
+		{"parameter sendMessage.old removed", true},
+		{"method getMe changed return type from *User to *Bot", true},
+		{"method getStory added", false},
+		{"method removedMethod removed", true},
+	}
+
+	if len(c) != len(want) {
+		t.Fatalf("changes:\n%v\nwant:\n%v", c, want)
+	}
+
+	for i := range want {
+		if c[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, c[i], want[i])
+		}
+	}
+
+	if n := c.breaking(); n != 9 {
+		t.Errorf("breaking() = %d, want 9", n)
+	}
+
+	if n := diffAPI(models, models, requests, requests).breaking(); n != 0 {
+		t.Errorf("breaking() = %d without changes", n)
+	}
+}
+
+func TestChangelogWrite(t *testing.T) {
+	buf := new(bytes.Buffer)
+	changelog{}.write(buf)
+	if buf.String() != "No API changes\n" {
+		t.Errorf("empty changelog %q", buf.String())
+	}
+
+	buf.Reset()
+	changelog{{"method a added", false}, {"method b removed", true}}.write(buf)
+	want := "Breaking changes:\n- method b removed\nChanges:\n- method a added\n"
+	if buf.String() != want {
+		t.Errorf("changelog %q, want %q", buf.String(), want)
+	}
+}
+
+// TestStrict parses the fixture over data where sendMessage.text was optional,
+// so it becomes required.
+func TestStrict(t *testing.T) {
+	dir := setup(t)
+	*input = "testdata/api.html"
+	*strict = true
+
+	var requests []*APIMethod
+	if err := loadPrevious(filepath.Join("testdata", "golden", "requests.json"), &requests); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range requests {
+		for i := range m.Params {
+			if m.Name == "sendMessage" && m.Params[i].Name == "text" {
+				m.Params[i].Required = false
+			}
+		}
+	}
+
+	previous := filepath.Join(dir, "data", "requests.json")
+	if err := os.MkdirAll(filepath.Dir(previous), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeJSON(previous, requests); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := os.ReadFile(previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			if err == nil || !strings.Contains(err.Error(), "1 breaking changes") {
+				t.Errorf("run() stopped with %v, want 1 breaking change", err)
+			}
+		}()
+
+		run()
+	}()
+
+	text, err := os.ReadFile(*changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(text), "Breaking changes:\n- parameter sendMessage.text became required\n") {
+		t.Errorf("changelog:\n%s", text)
+	}
+
+	// nothing is written
+	data, err := os.ReadFile(previous)
+	if err != nil || !bytes.Equal(data, written) {
+		t.Errorf("data/requests.json was changed: %v", err)
+	}
+
+	for _, name := range generated {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			t.Errorf("%s was written", name)
+		}
+	}
+}
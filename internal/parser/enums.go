@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// APIEnum is a string field or parameter with a documented set of values,
+// generated as a string type with constants.
+type APIEnum struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Fields      []string    `json:"fields"`
+	Values      []EnumValue `json:"values"`
+}
+
+type EnumValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+var (
+	quotedValue = regexp.MustCompile(`"([^"]+)"`)
+	italicValue = regexp.MustCompile(`\*([a-z_]+)\*`)
+)
+
+// enumSpec describes where an enum is used and how to extract its values.
+// Fields are "Owner.field", where owner is an object or a method name, or "*"
+// for any of them. Values are taken from descriptions of the source fields,
+// unless they are fixed.
+type enumSpec struct {
+	name        string
+	description string
+	fields      []string
+	sources     []string
+	pattern     *regexp.Regexp
+	values      []string
+	names       map[string]string
+}
+
+var enumSpecs = []enumSpec{
+	{
+		name:        "ChatType",
+		description: "ChatType is the type of a chat. ChatTypeSender is used by inline queries only, for a private chat with the sender.",
+		fields:      []string{"Chat.type", "InlineQuery.chat_type"},
+		sources:     []string{"Chat.type", "InlineQuery.chat_type"},
+	},
+	{
+		name:        "MessageEntityType",
+		description: "MessageEntityType is the type of a MessageEntity.",
+		fields:      []string{"MessageEntity.type"},
+		sources:     []string{"MessageEntity.type"},
+	},
+	{
+		name:        "PollType",
+		description: "PollType is the type of a poll.",
+		fields:      []string{"Poll.type", "sendPoll.type"},
+		sources:     []string{"Poll.type"},
+	},
+	{
+		name:        "StickerType",
+		description: "StickerType is the type of a sticker or of a sticker set.",
+		fields:      []string{"Sticker.type", "StickerSet.sticker_type", "createNewStickerSet.sticker_type"},
+		sources:     []string{"Sticker.type"},
+	},
+	{
+		name:        "MaskPoint",
+		description: "MaskPoint is the part of the face a mask is placed relative to.",
+		fields:      []string{"MaskPosition.point"},
+		sources:     []string{"MaskPosition.point"},
+	},
+	{
+		name:        "ChatAction",
+		description: "ChatAction is the action broadcast with sendChatAction.",
+		fields:      []string{"sendChatAction.action"},
+		sources:     []string{"sendChatAction.action"},
+		pattern:     italicValue,
+	},
+	{
+		name:        "DiceEmoji",
+		description: "DiceEmoji is the emoji a dice throw animation is based on.",
+		fields:      []string{"Dice.emoji", "sendDice.emoji"},
+		sources:     []string{"sendDice.emoji"},
+		names: map[string]string{
+			"🎲": "Dice",
+			"🎯": "Darts",
+			"🏀": "Basketball",
+			"⚽": "Football",
+			"🎳": "Bowling",
+			"🎰": "SlotMachine",
+		},
+	},
+	{
+		// the modes are listed in the "Formatting options" section only
+		name:        "ParseMode",
+		description: "ParseMode is the mode for parsing entities in a text, see formatting options in the Bot API documentation.",
+		fields:      []string{"*.parse_mode"},
+		values:      []string{"MarkdownV2", "HTML", "Markdown"},
+	},
+	{
+		// filled with the fields of Update by resolveEnums
+		name:        "UpdateType",
+		description: "UpdateType is the name of an optional field of Update, as used by allowed_updates.",
+		fields:      []string{"*.allowed_updates"},
+	},
+}
+
+// knownEnums maps "Owner.field" and "*.field" to enums.
+var knownEnums = map[string]*APIEnum{}
+
+// resolveEnums extracts enum values from descriptions. Enums without values
+// are skipped, so their fields stay strings.
+func resolveEnums(models []*APIStruct, requests []*APIMethod) []*APIEnum {
+	descriptions := map[string]string{}
+	for _, m := range models {
+		for _, f := range m.Fields {
+			descriptions[m.Name+"."+f.Field] = f.Description
+		}
+	}
+
+	for _, r := range requests {
+		for _, p := range r.Params {
+			descriptions[r.Name+"."+p.Name] = p.Description
+		}
+	}
+
+	enums := make([]*APIEnum, 0, len(enumSpecs))
+	for _, spec := range enumSpecs {
+		values := spec.values
+		if spec.name == "UpdateType" {
+			values = updateTypes(models)
+		}
+
+		pattern := spec.pattern
+		if pattern == nil {
+			pattern = quotedValue
+		}
+
+		for _, source := range spec.sources {
+			for _, match := range pattern.FindAllStringSubmatch(descriptions[source], -1) {
+				values = append(values, match[1])
+			}
+		}
+
+		e := &APIEnum{
+			Name:        spec.name,
+			Description: spec.description,
+			Fields:      spec.fields,
+		}
+
+		seen := map[string]bool{}
+		for _, v := range values {
+			if seen[v] {
+				continue
+			}
+
+			seen[v] = true
+			name, ok := spec.names[v]
+			if !ok {
+				name = toConstName(v)
+			}
+
+			e.Values = append(e.Values, EnumValue{Name: spec.name + name, Value: v})
+		}
+
+		if len(e.Values) == 0 {
+			continue
+		}
+
+		for _, f := range e.Fields {
+			knownEnums[f] = e
+		}
+
+		enums = append(enums, e)
+	}
+
+	return enums
+}
+
+func updateTypes(models []*APIStruct) []string {
+	for _, m := range models {
+		if m.Name != "Update" {
+			continue
+		}
+
+		types := []string{}
+		for _, f := range m.Fields {
+			if f.Optional {
+				types = append(types, f.Field)
+			}
+		}
+
+		return types
+	}
+
+	return nil
+}
+
+func toConstName(value string) string {
+	name := ""
+	for _, part := range strings.Split(value, "_") {
+		if part != "" {
+			name += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+
+	return name
+}
+
+// fieldType returns the Go type of a field or a parameter of owner.
+func fieldType(owner, field, t string) string {
+	e := knownEnums[owner+"."+field]
+	if e == nil {
+		e = knownEnums["*."+field]
+	}
+
+	if e == nil {
+		return toGoType(t)
+	}
+
+	prefix, elem := "", t
+	for strings.HasPrefix(elem, "[") {
+		prefix += "[]"
+		elem = elem[1 : len(elem)-1]
+	}
+
+	if elem != "string" {
+		return toGoType(t)
+	}
+
+	return prefix + e.Name
+}
+
+func writeEnum(it *APIEnum, buf *bytes.Buffer) {
+	buf.WriteString("\n\n")
+	writeMultilineComment(buf, "// ", it.Description)
+	buf.WriteString("type ")
+	buf.WriteString(it.Name)
+	buf.WriteString(" string\n\nconst (\n")
+	for _, v := range it.Values {
+		buf.WriteByte('\t')
+		buf.WriteString(v.Name)
+		buf.WriteByte(' ')
+		buf.WriteString(it.Name)
+		buf.WriteString(" = \"")
+		buf.WriteString(v.Value)
+		buf.WriteString("\"\n")
+	}
+	buf.WriteByte(')')
+}
@@ -3,11 +3,19 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/iamdimka/go-html"
@@ -22,14 +30,125 @@ func must(err error, msg ...interface{}) {
 	}
 }
 
-var knownStructs = map[string]bool{}
+var knownStructs = map[string]bool{
+	// InputFile has no table in the docs, it is implemented by hand in input-file.go
+	"InputFile": true,
+}
+
+// typeOverrides replaces documented types of fields and parameters, keyed by
+// "Owner.name".
+var typeOverrides = map[string]string{
+	// compared with Update.update_id, which is int64
+	"getUpdates.offset": "int64",
+}
+
+var (
+	sourceURL = flag.String("url", "https://core.telegram.org/bots/api", "URL of the Bot API documentation")
+	input     = flag.String("input", "", "read the documentation from a saved HTML file instead of -url")
+	snapshot  = flag.String("snapshot", "", "save the documentation to a file, e.g. to pin the API revision")
+	outDir    = flag.String("out", ".", "directory to write data/*.json and the generated files to")
+	changes   = flag.String("changelog", "", "write the changes against the previous data/*.json to a file instead of stdout")
+	strict    = flag.Bool("strict", false, "fail without writing anything if the API has breaking changes")
+	fromData  = flag.Bool("data", false, "regenerate the Go files from data/*.json instead of the documentation")
+)
+
+// loadDocument reads the documentation from -input or downloads it from -url.
+func loadDocument() (io.Reader, error) {
+	var data []byte
+	if *input != "" {
+		b, err := os.ReadFile(*input)
+		if err != nil {
+			return nil, err
+		}
+
+		data = b
+	} else {
+		res, err := http.Get(*sourceURL)
+		if err != nil {
+			return nil, err
+		}
+
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s: %s", *sourceURL, res.Status)
+		}
+
+		if data, err = io.ReadAll(res.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	if *snapshot != "" {
+		if err := os.WriteFile(*snapshot, data, 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+// report prints the changes against the previous data/*.json and stops on
+// breaking ones in the strict mode.
+func report(models []*APIStruct, requests []*APIMethod) {
+	oldModels := make([]*APIStruct, 0)
+	oldRequests := make([]*APIMethod, 0)
+	must(loadPrevious(output("data/models.json"), &oldModels), "could not read previous models")
+	must(loadPrevious(output("data/requests.json"), &oldRequests), "could not read previous requests")
+
+	c := diffAPI(oldModels, models, oldRequests, requests)
+
+	w := io.Writer(os.Stdout)
+	if *changes != "" {
+		file, err := os.Create(*changes)
+		must(err, "could not create the changelog")
+		defer file.Close()
+		w = file
+	}
+
+	c.write(w)
+
+	if n := c.breaking(); *strict && n > 0 {
+		must(fmt.Errorf("%d breaking changes, nothing is written", n))
+	}
+}
+
+func output(name string) string {
+	return filepath.Join(*outDir, name)
+}
 
 func main() {
-	res, err := http.Get("https://core.telegram.org/bots/api")
-	must(err, "could not perform the request")
+	flag.Parse()
+	run()
+}
+
+func run() {
+	must(os.MkdirAll(output("data"), 0o755))
 
-	root, err := html.Parse(res.Body)
-	must(err, "could not parse html document")
+	var models []*APIStruct
+	var requests []*APIMethod
+	if *fromData {
+		models, requests = loadData()
+	} else {
+		document, err := loadDocument()
+		must(err, "could not load the documentation")
+
+		models, requests, err = parseDocument(document)
+		must(err, "could not parse html document")
+
+		report(models, requests)
+
+		must(writeJSON(output("data/models.json"), models))
+		must(writeJSON(output("data/requests.json"), requests))
+	}
+
+	generate(models, requests)
+}
+
+func parseDocument(document io.Reader) ([]*APIStruct, []*APIMethod, error) {
+	root, err := html.Parse(document)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	body := root.QuerySelector("body")
 
@@ -48,9 +167,38 @@ func main() {
 		}
 	}
 
-	must(writeJSON("data/models.json", models))
-	must(writeJSON("data/requests.json", requests))
-	must(writeGo("models.go", "telegram", models, func(it *APIStruct, buf *bytes.Buffer) {
+	return models, requests, nil
+}
+
+// loadData reads the API parsed before from data/*.json.
+func loadData() ([]*APIStruct, []*APIMethod) {
+	models := make([]*APIStruct, 0)
+	requests := make([]*APIMethod, 0)
+	unions := make([]*APIUnion, 0)
+	must(loadPrevious(output("data/models.json"), &models), "could not read models")
+	must(loadPrevious(output("data/requests.json"), &requests), "could not read requests")
+	must(loadPrevious(output("data/unions.json"), &unions), "could not read unions")
+
+	for _, m := range models {
+		knownStructs[m.Name] = true
+	}
+
+	for _, u := range unions {
+		knownUnions[u.Name] = u
+	}
+
+	return models, requests
+}
+
+// generate writes data/unions.json, data/enums.json and the Go files.
+func generate(models []*APIStruct, requests []*APIMethod) {
+	resolveUnions(models, requests)
+	must(writeJSON(output("data/unions.json"), sortedUnions()))
+
+	enums := resolveEnums(models, requests)
+	must(writeJSON(output("data/enums.json"), enums))
+	must(writeGo(output("enums.go"), "telegram", enums, writeEnum))
+	must(writeGo(output("models.go"), "telegram", models, func(it *APIStruct, buf *bytes.Buffer) {
 		buf.WriteString("\n\n")
 		writeMultilineComment(buf, "// ", it.Description)
 		if it.Additional != "" {
@@ -65,7 +213,7 @@ func main() {
 			buf.WriteByte('\t')
 			buf.WriteString(toFieldName(f.Field))
 			buf.WriteByte(' ')
-			buf.WriteString(toGoType(f.Type))
+			buf.WriteString(fieldType(it.Name, f.Field, f.Type))
 			buf.WriteString(" `json:\"")
 			buf.WriteString(f.Field)
 			if f.Optional {
@@ -74,9 +222,10 @@ func main() {
 			buf.WriteString("\"`\n")
 		}
 		buf.WriteByte('}')
+		writeUnionFields(models)(it, buf)
 	}))
 
-	must(writeGo("requests.go", "telegram", requests, func(it *APIMethod, buf *bytes.Buffer) {
+	must(writeGo(output("requests.go"), "telegram", requests, func(it *APIMethod, buf *bytes.Buffer) {
 		buf.WriteString("\n\n")
 
 		writeMultilineComment(buf, "// ", it.Description)
@@ -95,7 +244,7 @@ func main() {
 			buf.WriteByte('\t')
 			buf.WriteString(toFieldName(p.Name))
 			buf.WriteByte(' ')
-			buf.WriteString(toGoType(p.Type))
+			buf.WriteString(fieldType(it.Name, p.Name, p.Type))
 			buf.WriteString(" `json:\"")
 			buf.WriteString(p.Name)
 			if !p.Required {
@@ -106,28 +255,59 @@ func main() {
 		buf.WriteByte('}')
 	}))
 
-	must(writeGo("api.go", "telegram", requests, func(it *APIMethod, buf *bytes.Buffer) {
+	must(writeGo(output("api.go"), "telegram", requests, func(it *APIMethod, buf *bytes.Buffer) {
 		returnType := toGoType(it.Return)
+		name := toFieldName(it.Name)
 
 		buf.WriteString("\n\n")
 		buf.WriteString("func (b *Bot) ")
-		buf.WriteString(toFieldName(it.Name))
+		buf.WriteString(name)
 		buf.WriteByte('(')
 		if len(it.Params) > 0 {
 			buf.WriteString("request *")
-			buf.WriteString(toFieldName(it.Name))
+			buf.WriteString(name)
 			buf.WriteString("Request")
 		}
 		buf.WriteString(") (result ")
 		buf.WriteString(returnType)
 		buf.WriteString(", err error) {\n")
-		if returnType[0] == '*' {
+		buf.WriteString("\treturn b.")
+		buf.WriteString(name)
+		buf.WriteString("Ctx(context.Background()")
+		if len(it.Params) > 0 {
+			buf.WriteString(", request")
+		}
+		buf.WriteString(")\n")
+		buf.WriteByte('}')
+
+		buf.WriteString("\n\n")
+		buf.WriteString("func (b *Bot) ")
+		buf.WriteString(name)
+		buf.WriteString("Ctx(ctx context.Context")
+		if len(it.Params) > 0 {
+			buf.WriteString(", request *")
+			buf.WriteString(name)
+			buf.WriteString("Request")
+		}
+		buf.WriteString(") (result ")
+		buf.WriteString(returnType)
+		buf.WriteString(", err error) {\n")
+		union, array := unionOf(it.Return)
+		if union != nil && !union.decodable(models) {
+			union = nil
+		}
+
+		if union != nil {
+			buf.WriteString("\tvar raw ")
+			buf.WriteString(strings.Repeat("[]", array))
+			buf.WriteString("json.RawMessage\n")
+		} else if returnType[0] == '*' {
 			buf.WriteString("\tresult = &")
 			buf.WriteString(returnType[1:])
 			buf.WriteString("{}")
 			buf.WriteByte('\n')
 		}
-		buf.WriteString("\terr = b.request(\"")
+		buf.WriteString("\terr = b.request(ctx, \"")
 		buf.WriteString(it.Name)
 		buf.WriteByte('"')
 		if len(it.Params) > 0 {
@@ -136,19 +316,32 @@ func main() {
 			buf.WriteString(", nil")
 		}
 		buf.WriteString(", ")
-		if returnType[0] != '*' {
-			buf.WriteByte('&')
-		}
-		buf.WriteString("result)\n")
-		if returnType[0] == '*' {
-			buf.WriteString("\tif err != nil {\n\t\tresult = nil;\n\t}\n")
+		if union != nil {
+			buf.WriteString("&raw)\n")
+			buf.WriteString("\tif err == nil {\n\t\tresult, err = ")
+			buf.WriteString(unmarshalUnionCall(it.Return, "raw"))
+			buf.WriteString("\n\t}\n")
+		} else {
+			if returnType[0] != '*' {
+				buf.WriteByte('&')
+			}
+			buf.WriteString("result)\n")
+			if returnType[0] == '*' {
+				buf.WriteString("\tif err != nil {\n\t\tresult = nil\n\t}\n")
+			}
 		}
 		buf.WriteString("\treturn\n")
 		buf.WriteByte('}')
 	}))
+
+	must(writeGo(output("unions.go"), "telegram", sortedUnions(), writeUnion(models)))
 }
 
 func toGoType(t string) string {
+	if alias, ok := unionAliases[t]; ok {
+		t = alias
+	}
+
 	if strings.Contains(t, ", ") {
 		return "interface{}"
 	}
@@ -171,6 +364,10 @@ func toGoType(t string) string {
 
 	default:
 		if t[0] >= 'A' && t[0] <= 'Z' {
+			if knownUnions[t] != nil {
+				return prefix + t
+			}
+
 			if knownStructs[t] {
 				return prefix + "*" + t
 			}
@@ -182,32 +379,40 @@ func toGoType(t string) string {
 	}
 }
 
+// listItem matches the marker of a list item in a description.
+var listItem = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+`)
+
+// writeMultilineComment wraps each line of data at 80 characters. Wrapped lines
+// of a list item are indented under its text, so gofmt keeps the item together.
 func writeMultilineComment(b *bytes.Buffer, prefix, data string) {
 	if data == "" {
 		return
 	}
 
-	for len(data) > 80 {
-		idx := strings.LastIndexByte(data[:80], ' ')
-		if idx < 0 {
-			idx = strings.IndexByte(data, ' ')
-		}
+	for _, line := range strings.Split(data, "\n") {
+		indent := strings.Repeat(" ", len(listItem.FindString(line)))
 
-		if idx < 0 {
-			break
+		for len(line) > 80 {
+			idx := strings.LastIndexByte(line[:80], ' ')
+			if idx <= len(indent) {
+				idx = strings.IndexByte(line[len(indent)+1:], ' ')
+				if idx < 0 {
+					break
+				}
+				idx += len(indent) + 1
+			}
+
+			writeCommentLine(b, prefix, line[:idx])
+			line = indent + line[idx+1:]
 		}
 
-		b.WriteString(prefix)
-		b.WriteString(strings.ReplaceAll(data[:idx], "\n", "\n"+prefix))
-		b.WriteByte('\n')
-		data = data[idx+1:]
+		writeCommentLine(b, prefix, line)
 	}
+}
 
-	if data != "" {
-		b.WriteString(prefix)
-		b.WriteString(strings.ReplaceAll(data, "\n", "\n"+prefix))
-		b.WriteByte('\n')
-	}
+func writeCommentLine(b *bytes.Buffer, prefix, line string) {
+	b.WriteString(strings.TrimRight(prefix+line, " "))
+	b.WriteByte('\n')
 }
 
 func toFieldName(name string) string {
@@ -238,26 +443,82 @@ func writeJSON(filename string, data interface{}) error {
 	return enc.Encode(data)
 }
 
+// packages maps package names used by the generated code to import paths.
+var packages = map[string]string{
+	"context": "context",
+	"fmt":     "fmt",
+	"json":    "encoding/json",
+}
+
 func writeGo[T interface{}](filename, packageName string, data []T, fn func(it T, buf *bytes.Buffer)) error {
 	b := new(bytes.Buffer)
 
+	b.WriteString("// Code generated by internal/parser from the Bot API documentation. DO NOT EDIT.\n\n")
 	b.WriteString("package ")
 	b.WriteString(packageName)
 	b.WriteString("\n\n")
-	b.WriteString(`import "encoding/json"`)
 
+	body := new(bytes.Buffer)
 	for _, it := range data {
-		fn(it, b)
+		fn(it, body)
 	}
 
-	file, err := os.Create(filename)
+	imports, err := usedImports(packageName, body.Bytes())
 	if err != nil {
-		return err
+		return fmt.Errorf("%s: %w", filename, err)
 	}
 
-	defer file.Close()
-	_, err = b.WriteTo(file)
-	return err
+	switch len(imports) {
+	case 0:
+	case 1:
+		b.WriteString(`import "`)
+		b.WriteString(imports[0])
+		b.WriteByte('"')
+	default:
+		b.WriteString("import (\n")
+		for _, it := range imports {
+			b.WriteString("\t\"")
+			b.WriteString(it)
+			b.WriteString("\"\n")
+		}
+		b.WriteByte(')')
+	}
+
+	b.Write(body.Bytes())
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return os.WriteFile(filename, src, 0o644)
+}
+
+// usedImports returns import paths of the packages referenced by the code.
+func usedImports(packageName string, code []byte) ([]string, error) {
+	src := append([]byte("package "+packageName+"\n"), code...)
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && packages[x.Name] != "" {
+				used[packages[x.Name]] = true
+			}
+		}
+		return true
+	})
+
+	imports := make([]string, 0, len(used))
+	for it := range used {
+		imports = append(imports, it)
+	}
+
+	sort.Strings(imports)
+	return imports, nil
 }
 
 func parse(node *html.Node) interface{} {
@@ -303,7 +564,7 @@ func parse(node *html.Node) interface{} {
 
 				method.Params = append(method.Params, APIParameter{
 					Name:        name,
-					Type:        parseType(td[1], name),
+					Type:        overrideType(title, name, parseType(td[1], name)),
 					Required:    strings.EqualFold(td[2].InnerText(), "Yes"),
 					Description: strings.TrimSpace(parseText(td[3])),
 				})
@@ -313,6 +574,13 @@ func parse(node *html.Node) interface{} {
 	}
 
 	if table == nil {
+		list := node.Next("ul")
+		if list != nil && list.Between(node, next) {
+			if u := parseUnion(title, desc, list); u != nil {
+				return u
+			}
+		}
+
 		return nil
 	}
 
@@ -330,7 +598,7 @@ func parse(node *html.Node) interface{} {
 
 		s.Fields = append(s.Fields, APIField{
 			Field:       field,
-			Type:        parseType(td[1], field),
+			Type:        overrideType(title, field, parseType(td[1], field)),
 			Optional:    td[2].QuerySelector("em") != nil,
 			Description: strings.TrimSpace(parseText(td[2])),
 		})
@@ -339,6 +607,14 @@ func parse(node *html.Node) interface{} {
 	return s
 }
 
+func overrideType(owner, name, t string) string {
+	if override, ok := typeOverrides[owner+"."+name]; ok {
+		return override
+	}
+
+	return t
+}
+
 func parseReturn(text string) string {
 	for _, tpl := range []string{
 		`([aA]n [aA]rray of).+?([A-Z][a-zA-Z]+).+?is returned`,
@@ -481,7 +757,16 @@ func parseType(node *html.Node, name string) string {
 
 		return formatType("int", array)
 
-	case "string", "boolean", "true", "float", "float number":
+	case "string":
+		// InputMedia* objects describe the media field as a string accepting
+		// file_id, URL or attach://<name>, which is exactly what InputFile does
+		if name == "media" {
+			return formatType("InputFile", array)
+		}
+
+		return formatType(data, array)
+
+	case "boolean", "true", "float", "float number":
 		return formatType(data, array)
 
 	default:
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update testdata/golden")
+
+var generated = []string{"models.go", "requests.go", "api.go", "unions.go", "enums.go"}
+
+// setup resets the state of a previous run and writes the output to a
+// temporary directory.
+func setup(t *testing.T) string {
+	t.Helper()
+
+	knownStructs = map[string]bool{"InputFile": true}
+	knownUnions = map[string]*APIUnion{}
+	unionAliases = map[string]string{}
+	knownEnums = map[string]*APIEnum{}
+
+	dir := t.TempDir()
+	*outDir = dir
+	*input = ""
+	*fromData = false
+	*strict = false
+	*changes = filepath.Join(dir, "changes.txt")
+	return dir
+}
+
+func compareFiles(t *testing.T, got, want string) {
+	t.Helper()
+
+	gotData, err := os.ReadFile(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantData, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(gotData, wantData) {
+		t.Errorf("%s differs from %s", got, want)
+	}
+}
+
+func TestParseFixture(t *testing.T) {
+	dir := setup(t)
+	*input = "testdata/api.html"
+	run()
+
+	files := append([]string{"data/models.json", "data/requests.json", "data/unions.json", "data/enums.json"}, generated...)
+	for _, name := range files {
+		golden := filepath.Join("testdata", "golden", filepath.Base(name))
+		if *update {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(golden, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		compareFiles(t, filepath.Join(dir, name), golden)
+	}
+
+	// there is no previous data, everything is added
+	text, err := os.ReadFile(*changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(string(text), "Changes:\n- type Update added\n") || strings.Contains(string(text), "Breaking") {
+		t.Errorf("changelog:\n%s", text)
+	}
+
+	// whitespace is normalized, as gofmt aligns fields and constants
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.Join(strings.Fields(string(data)), " ")
+	}
+
+	for name, snippets := range map[string][]string{
+		"models.go": {
+			"Type ChatType `json:\"type\"`",
+			"Title string `json:\"title,omitempty\"`",
+			"InlineKeyboard [][]*InlineKeyboardButton `json:\"inline_keyboard\"`",
+			"NewChatMember ChatMember `json:\"new_chat_member\"`",
+		},
+		"requests.go": {
+			"Offset int64 `json:\"offset,omitempty\"`",
+			"AllowedUpdates []UpdateType `json:\"allowed_updates,omitempty\"`",
+			"ParseMode ParseMode `json:\"parse_mode,omitempty\"`",
+			"ReplyMarkup ReplyMarkup `json:\"reply_markup,omitempty\"`",
+			"ChatId interface{} `json:\"chat_id\"`",
+		},
+		"api.go": {
+			"func (b *Bot) GetMeCtx(ctx context.Context) (result *User, err error) {",
+			"func (b *Bot) GetUpdatesCtx(ctx context.Context, request *GetUpdatesRequest) (result []*Update, err error) {",
+			"result, err = unmarshalChatMember(raw)",
+		},
+		"unions.go": {
+			"case \"creator\":\n\t\tresult = &ChatMemberOwner{}",
+			"return &UnknownChatMember{Status: probe.Status",
+		},
+		"enums.go": {
+			"ChatTypeSupergroup ChatType = \"supergroup\"",
+			"UpdateTypeChatMember UpdateType = \"chat_member\"",
+			"ParseModeHTML ParseMode = \"HTML\"",
+		},
+	} {
+		code := read(name)
+		for _, snippet := range snippets {
+			if !strings.Contains(code, strings.Join(strings.Fields(snippet), " ")) {
+				t.Errorf("%s doesn't contain %q", name, snippet)
+			}
+		}
+	}
+}
+
+// TestGeneratedFilesUpToDate regenerates the package from data/*.json, the
+// result must be the same as the committed files.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	dir := setup(t)
+	*fromData = true
+
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"models.json", "requests.json", "unions.json"} {
+		data, err := os.ReadFile(filepath.Join("..", "..", "data", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "data", name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run()
+
+	for _, name := range append([]string{"data/unions.json", "data/enums.json"}, generated...) {
+		compareFiles(t, filepath.Join(dir, name), filepath.Join("..", "..", name))
+	}
+}
+
+func TestWriteMultilineComment(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeMultilineComment(buf, "// ", "There could be two ways:\n\n"+
+		"  - Explain the user how to send a command with parameters (e.g. /newpoll question answer1 answer2).\n\n"+
+		"- Guide the user through a step-by-step process, then send /done when you're ready.\n"+
+		"The last option is definitely more attractive. And if you use ForceReply in your bot's questions, it works.")
+
+	want := `// There could be two ways:
+//
+//   - Explain the user how to send a command with parameters (e.g. /newpoll
+//     question answer1 answer2).
+//
+// - Guide the user through a step-by-step process, then send /done when you're
+//   ready.
+// The last option is definitely more attractive. And if you use ForceReply in
+// your bot's questions, it works.
+`
+	if buf.String() != want {
+		t.Errorf("comment:\n%s\nwant:\n%s", buf, want)
+	}
+}
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iamdimka/go-html"
+)
+
+// APIUnion is an object documented as "one of" other objects, e.g.
+// ChatMember, InputMedia or BotCommandScope. Variants are told apart by the
+// Discriminator field, if all of them have it.
+type APIUnion struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description,omitempty"`
+	Discriminator string   `json:"discriminator,omitempty"`
+	Variants      []string `json:"variants"`
+}
+
+var knownUnions = map[string]*APIUnion{}
+
+// unionAliases maps inline unions of parameter types (e.g. reply_markup's
+// "InlineKeyboardMarkup, ReplyKeyboardMarkup, ...") to a named union type
+var unionAliases = map[string]string{}
+
+var discriminators = []string{"type", "status", "source"}
+
+var discriminatorValue = regexp.MustCompile(`(?:always|must be) \*?"?([a-z_0-9]+)`)
+
+// parseUnion recognises a section without a table followed by a list whose
+// items are links to other objects only.
+func parseUnion(title, description string, list *html.Node) *APIUnion {
+	if strings.ContainsAny(title, " ") {
+		return nil
+	}
+
+	u := &APIUnion{
+		Name:        title,
+		Description: description,
+	}
+
+	for _, li := range list.QuerySelectorAll("li") {
+		a := li.QuerySelector("a")
+		if a == nil {
+			return nil
+		}
+
+		name := strings.TrimSpace(a.InnerText())
+		if name == "" || name[0] < 'A' || name[0] > 'Z' || strings.TrimSpace(li.InnerText()) != name {
+			return nil
+		}
+
+		u.Variants = append(u.Variants, name)
+	}
+
+	if len(u.Variants) == 0 {
+		return nil
+	}
+
+	knownUnions[title] = u
+	return u
+}
+
+// resolveUnions finds discriminators of parsed unions and names inline unions
+// used by fields and parameters.
+func resolveUnions(models []*APIStruct, requests []*APIMethod) {
+	for _, u := range knownUnions {
+		u.Discriminator = ""
+
+		for _, d := range discriminators {
+			found := true
+			for _, v := range u.Variants {
+				if variantValue(models, v, d) == "" {
+					found = false
+					break
+				}
+			}
+
+			if found {
+				u.Discriminator = d
+				break
+			}
+		}
+	}
+
+	for _, m := range models {
+		for _, f := range m.Fields {
+			resolveInlineUnion(f.Field, f.Type)
+		}
+	}
+
+	for _, r := range requests {
+		for _, p := range r.Params {
+			resolveInlineUnion(p.Name, p.Type)
+		}
+	}
+}
+
+func resolveInlineUnion(name, t string) {
+	if !strings.Contains(t, ", ") || unionAliases[t] != "" {
+		return
+	}
+
+	array := -1
+	variants := []string{}
+	for _, it := range strings.Split(t, ", ") {
+		depth := 0
+		for strings.HasPrefix(it, "[") {
+			it = it[1 : len(it)-1]
+			depth++
+		}
+
+		if !knownStructs[it] || (array >= 0 && array != depth) {
+			return
+		}
+
+		array = depth
+		variants = append(variants, it)
+	}
+
+	prefix := strings.Repeat("[", array)
+	suffix := strings.Repeat("]", array)
+
+	// a subset of a documented union, like sendMediaGroup's media
+	for _, u := range sortedUnions() {
+		if containsAll(u.Variants, variants) {
+			unionAliases[t] = prefix + u.Name + suffix
+			return
+		}
+	}
+
+	u := &APIUnion{
+		Name:        toFieldName(name),
+		Description: "One of " + strings.Join(variants[:len(variants)-1], ", ") + " or " + variants[len(variants)-1],
+		Variants:    variants,
+	}
+
+	knownUnions[u.Name] = u
+	unionAliases[t] = prefix + u.Name + suffix
+}
+
+func containsAll(list []string, items []string) bool {
+	for _, it := range items {
+		found := false
+		for _, v := range list {
+			if v == it {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortedUnions() []*APIUnion {
+	unions := make([]*APIUnion, 0, len(knownUnions))
+	for _, it := range knownUnions {
+		unions = append(unions, it)
+	}
+
+	sort.Slice(unions, func(i, j int) bool {
+		return unions[i].Name < unions[j].Name
+	})
+
+	return unions
+}
+
+// unionOf returns the union used by type t (possibly an array of unions) and
+// the number of array dimensions.
+func unionOf(t string) (*APIUnion, int) {
+	if alias, ok := unionAliases[t]; ok {
+		t = alias
+	}
+
+	array := 0
+	for strings.HasPrefix(t, "[") {
+		t = t[1 : len(t)-1]
+		array++
+	}
+
+	return knownUnions[t], array
+}
+
+// decodable reports whether the union can be unmarshaled, i.e. every variant
+// has its own discriminator value.
+func (u *APIUnion) decodable(models []*APIStruct) bool {
+	if u.Discriminator == "" {
+		return false
+	}
+
+	seen := map[string]bool{}
+	for _, v := range u.Variants {
+		value := variantValue(models, v, u.Discriminator)
+		if seen[value] {
+			return false
+		}
+
+		seen[value] = true
+	}
+
+	return true
+}
+
+func variantValue(models []*APIStruct, variant, discriminator string) string {
+	for _, s := range models {
+		if s.Name != variant {
+			continue
+		}
+
+		for _, f := range s.Fields {
+			if f.Field != discriminator {
+				continue
+			}
+
+			if res := discriminatorValue.FindStringSubmatch(f.Description); res != nil {
+				return res[1]
+			}
+		}
+	}
+
+	return ""
+}
+
+func writeUnion(models []*APIStruct) func(it *APIUnion, buf *bytes.Buffer) {
+	return func(it *APIUnion, buf *bytes.Buffer) {
+		marker := "is" + it.Name
+
+		buf.WriteString("\n\n")
+		writeMultilineComment(buf, "// ", it.Description)
+		buf.WriteString("type ")
+		buf.WriteString(it.Name)
+		buf.WriteString(" interface {\n\t")
+		buf.WriteString(marker)
+		buf.WriteString("()\n}\n")
+
+		for _, v := range it.Variants {
+			buf.WriteString("\nfunc (*")
+			buf.WriteString(v)
+			buf.WriteString(") ")
+			buf.WriteString(marker)
+			buf.WriteString("() {}\n")
+		}
+
+		if it.Discriminator == "" {
+			return
+		}
+
+		field := toFieldName(it.Discriminator)
+
+		// the discriminator is filled on marshaling, so it doesn't have to be set by hand
+		for _, v := range it.Variants {
+			buf.WriteString("\nfunc (it *")
+			buf.WriteString(v)
+			buf.WriteString(") MarshalJSON() ([]byte, error) {\n")
+			buf.WriteString("\ttype alias ")
+			buf.WriteString(v)
+			buf.WriteString("\n\treturn json.Marshal(struct {\n\t\t")
+			buf.WriteString(field)
+			buf.WriteString(" string `json:\"")
+			buf.WriteString(it.Discriminator)
+			buf.WriteString("\"`\n\t\t*alias\n\t}{\"")
+			buf.WriteString(variantValue(models, v, it.Discriminator))
+			buf.WriteString("\", (*alias)(it)})\n}\n")
+		}
+
+		if !it.decodable(models) {
+			return
+		}
+
+		// variants added to the API later are kept as raw JSON, failing would
+		// drop the whole update containing them
+		unknown := "Unknown" + it.Name
+		buf.WriteByte('\n')
+		writeMultilineComment(buf, "// ", unknown+" is a "+it.Name+" with a "+it.Discriminator+" unknown to this package. Raw is the whole object, it is sent as is when marshaled.")
+		buf.WriteString("type ")
+		buf.WriteString(unknown)
+		buf.WriteString(" struct {\n\t")
+		buf.WriteString(field)
+		buf.WriteString(" string\n\tRaw json.RawMessage\n}\n\n")
+		buf.WriteString("func (*")
+		buf.WriteString(unknown)
+		buf.WriteString(") ")
+		buf.WriteString(marker)
+		buf.WriteString("() {}\n\n")
+		buf.WriteString("func (it *")
+		buf.WriteString(unknown)
+		buf.WriteString(") MarshalJSON() ([]byte, error) {\n\treturn it.Raw, nil\n}\n")
+
+		buf.WriteString("\nfunc unmarshal")
+		buf.WriteString(it.Name)
+		buf.WriteString("(data []byte) (")
+		buf.WriteString(it.Name)
+		buf.WriteString(", error) {\n")
+		buf.WriteString("\tif len(data) == 0 || string(data) == \"null\" {\n\t\treturn nil, nil\n\t}\n\n")
+		buf.WriteString("\tvar probe struct {\n\t\t")
+		buf.WriteString(field)
+		buf.WriteString(" string `json:\"")
+		buf.WriteString(it.Discriminator)
+		buf.WriteString("\"`\n\t}\n\n")
+		buf.WriteString("\tif err := json.Unmarshal(data, &probe); err != nil {\n\t\treturn nil, err\n\t}\n\n")
+		buf.WriteString("\tvar result ")
+		buf.WriteString(it.Name)
+		buf.WriteString("\n\tswitch probe.")
+		buf.WriteString(field)
+		buf.WriteString(" {\n")
+		for _, v := range it.Variants {
+			buf.WriteString("\tcase \"")
+			buf.WriteString(variantValue(models, v, it.Discriminator))
+			buf.WriteString("\":\n\t\tresult = &")
+			buf.WriteString(v)
+			buf.WriteString("{}\n")
+		}
+		buf.WriteString("\tdefault:\n\t\treturn &")
+		buf.WriteString(unknown)
+		buf.WriteString("{")
+		buf.WriteString(field)
+		buf.WriteString(": probe.")
+		buf.WriteString(field)
+		buf.WriteString(", Raw: append(json.RawMessage{}, data...)}, nil\n\t}\n\n")
+		buf.WriteString("\treturn result, json.Unmarshal(data, result)\n")
+		buf.WriteByte('}')
+	}
+}
+
+// writeUnionFields writes UnmarshalJSON for structs having decodable union
+// fields, because encoding/json can't decode into an interface.
+func writeUnionFields(models []*APIStruct) func(it *APIStruct, buf *bytes.Buffer) {
+	return func(it *APIStruct, buf *bytes.Buffer) {
+		fields := []APIField{}
+		for _, f := range it.Fields {
+			if u, _ := unionOf(f.Type); u != nil && u.decodable(models) {
+				fields = append(fields, f)
+			}
+		}
+
+		if len(fields) == 0 {
+			return
+		}
+
+		buf.WriteString("\n\nfunc (it *")
+		buf.WriteString(it.Name)
+		buf.WriteString(") UnmarshalJSON(data []byte) (err error) {\n")
+		buf.WriteString("\ttype alias ")
+		buf.WriteString(it.Name)
+		buf.WriteString("\n\tvar raw struct {\n\t\t*alias\n")
+		for _, f := range fields {
+			_, array := unionOf(f.Type)
+			buf.WriteString("\t\t")
+			buf.WriteString(toFieldName(f.Field))
+			buf.WriteByte(' ')
+			buf.WriteString(strings.Repeat("[]", array))
+			buf.WriteString("json.RawMessage `json:\"")
+			buf.WriteString(f.Field)
+			buf.WriteString("\"`\n")
+		}
+		buf.WriteString("\t}\n\n")
+		buf.WriteString("\traw.alias = (*alias)(it)\n")
+		buf.WriteString("\tif err = json.Unmarshal(data, &raw); err != nil {\n\t\treturn\n\t}\n")
+		for _, f := range fields {
+			name := toFieldName(f.Field)
+			buf.WriteString("\n\tif it.")
+			buf.WriteString(name)
+			buf.WriteString(", err = ")
+			buf.WriteString(unmarshalUnionCall(f.Type, "raw."+name))
+			buf.WriteString("; err != nil {\n\t\treturn\n\t}\n")
+		}
+		buf.WriteString("\n\treturn\n}")
+	}
+}
+
+func unmarshalUnionCall(t string, raw string) string {
+	u, array := unionOf(t)
+	if array > 0 {
+		return "unmarshalUnions(" + raw + ", unmarshal" + u.Name + ")"
+	}
+
+	return "unmarshal" + u.Name + "(" + raw + ")"
+}
@@ -0,0 +1,32 @@
+package telegram
+
+import "context"
+
+// RequestHandler performs an API call: sends request to method and decodes the
+// response into result.
+type RequestHandler func(ctx context.Context, method string, request interface{}, result interface{}) error
+
+// RequestMiddleware wraps API calls, e.g. to log them, collect metrics or
+// modify requests. It sees the typed request (like *SendMessageRequest, nil
+// for methods without parameters) and the pointer the result is decoded to.
+//
+// Results holding a union are decoded after the middleware returns, so
+// methods returning one (getChatMember, getChatMenuButton) pass a
+// *json.RawMessage and getChatAdministrators a *[]json.RawMessage. The
+// getUpdates calls of a Poller pass a *json.RawMessage too.
+type RequestMiddleware func(next RequestHandler) RequestHandler
+
+// Use adds middlewares around every API call made by the bot, including
+// getUpdates of pollers. The first middleware is the outermost. Use should be
+// called before the bot is used.
+func (b *Bot) Use(middlewares ...RequestMiddleware) {
+	b.middlewares = append(b.middlewares, middlewares...)
+}
+
+func (b *Bot) wrap(handler RequestHandler) RequestHandler {
+	for i := len(b.middlewares) - 1; i >= 0; i-- {
+		handler = b.middlewares[i](handler)
+	}
+
+	return handler
+}
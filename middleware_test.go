@@ -0,0 +1,113 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+)
+
+// messageBot answers sendMessage with the sent text, failing for chat 0, and
+// getChatMember with an owner.
+func messageBot(t *testing.T) *Bot {
+	return newTestBot(t, func(r *http.Request) interface{} {
+		if path.Base(r.URL.Path) == "getChatMember" {
+			return map[string]interface{}{"status": "creator", "user": map[string]interface{}{"id": 1}}
+		}
+
+		var request SendMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Error(err)
+		}
+
+		if request.ChatId == float64(0) {
+			return &APIError{ErrorCode: 400, Description: "Bad Request: chat not found"}
+		}
+
+		return &Message{MessageId: 1, Text: request.Text}
+	})
+}
+
+func TestBotUseOrder(t *testing.T) {
+	bot := messageBot(t)
+
+	var trace []string
+	for _, name := range []string{"a", "b"} {
+		name := name
+		bot.Use(func(next RequestHandler) RequestHandler {
+			return func(ctx context.Context, method string, request, result interface{}) error {
+				trace = append(trace, name+" "+method)
+				err := next(ctx, method, request, result)
+				trace = append(trace, name+" done")
+				return err
+			}
+		})
+	}
+
+	if _, err := bot.SendMessageCtx(context.Background(), &SendMessageRequest{ChatId: 1, Text: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := strings.Join(trace, ", "), "a sendMessage, b sendMessage, b done, a done"; got != want {
+		t.Errorf("trace %s, want %s", got, want)
+	}
+}
+
+func TestBotUseRequestAndResult(t *testing.T) {
+	bot := messageBot(t)
+
+	var results []interface{}
+	var errs []error
+	bot.Use(func(next RequestHandler) RequestHandler {
+		return func(ctx context.Context, method string, request, result interface{}) error {
+			// the request is replaced by a copy, the caller's one is kept
+			if send, ok := request.(*SendMessageRequest); ok {
+				changed := *send
+				changed.Text = strings.ToUpper(send.Text)
+				request = &changed
+			}
+
+			err := next(ctx, method, request, result)
+			results = append(results, result)
+			errs = append(errs, err)
+			return err
+		}
+	})
+
+	ctx := context.Background()
+	request := &SendMessageRequest{ChatId: 1, Text: "hi"}
+	message, err := bot.SendMessageCtx(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Text != "HI" || request.Text != "hi" {
+		t.Errorf("sent %q for the request %q, want HI", message.Text, request.Text)
+	}
+
+	// the middleware sees the decoded result and the error
+	if result, ok := results[0].(*Message); !ok || result != message || errs[0] != nil {
+		t.Errorf("middleware saw %T, %v", results[0], errs[0])
+	}
+
+	_, err = bot.SendMessageCtx(ctx, &SendMessageRequest{ChatId: 0, Text: "hi"})
+	if apiErr := asAPIError(err); apiErr == nil || apiErr.ErrorCode != 400 || errs[1] != err {
+		t.Errorf("err = %v, middleware saw %v", err, errs[1])
+	}
+
+	// unions are decoded after the middleware
+	member, err := bot.GetChatMemberCtx(ctx, &GetChatMemberRequest{ChatId: 1, UserId: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := member.(*ChatMemberOwner); !ok {
+		t.Errorf("GetChatMember() = %#v, want *ChatMemberOwner", member)
+	}
+
+	if raw, ok := results[2].(*json.RawMessage); !ok || !strings.Contains(string(*raw), `"creator"`) {
+		t.Errorf("middleware saw %T, want *json.RawMessage", results[2])
+	}
+}
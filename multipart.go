@@ -0,0 +1,167 @@
+package telegram
+
+import (
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// collectUploads finds every InputFile with a reader inside request and names
+// its multipart part. Top level files are sent under their own field name,
+// nested ones (e.g. inside InputMedia) are referenced via attach://fileN.
+func collectUploads(request interface{}) []*InputFile {
+	v := reflect.ValueOf(request)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var files []*InputFile
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if f, ok := v.Field(i).Interface().(*InputFile); ok {
+			if f.IsUpload() {
+				f.attach = jsonFieldName(field)
+				files = append(files, f)
+			}
+			continue
+		}
+
+		files = walkUploads(v.Field(i), files)
+	}
+
+	return files
+}
+
+func walkUploads(v reflect.Value, files []*InputFile) []*InputFile {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return files
+		}
+
+		if f, ok := v.Interface().(*InputFile); ok {
+			if f.IsUpload() {
+				f.attach = "file" + strconv.Itoa(len(files))
+				files = append(files, f)
+			}
+			return files
+		}
+
+		return walkUploads(v.Elem(), files)
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			files = walkUploads(v.Index(i), files)
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				files = walkUploads(v.Field(i), files)
+			}
+		}
+	}
+
+	return files
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+// encodeMultipart streams request as multipart/form-data. Plain fields are
+// encoded the same way as in JSON requests, strings are sent unquoted. The
+// request fails with ErrFileTooLarge when a file is bigger than maxSize.
+func (b *Bot) encodeMultipart(request interface{}, files []*InputFile, maxSize int64) (io.ReadCloser, string, error) {
+	data, err := b.JSONMarshal(request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err = b.JSONUnmarshal(data, &fields); err != nil {
+		return nil, "", err
+	}
+
+	for _, f := range files {
+		delete(fields, f.attach)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(b.writeMultipart(mw, keys, fields, files, maxSize))
+	}()
+
+	return pr, mw.FormDataContentType(), nil
+}
+
+func (b *Bot) writeMultipart(mw *multipart.Writer, keys []string, fields map[string]json.RawMessage, files []*InputFile, maxSize int64) error {
+	for _, key := range keys {
+		value := fields[key]
+		if len(value) > 0 && value[0] == '"' {
+			var s string
+			if err := b.JSONUnmarshal(value, &s); err != nil {
+				return err
+			}
+			value = []byte(s)
+		}
+
+		if err := mw.WriteField(key, string(value)); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range files {
+		name := f.Name
+		if name == "" {
+			name = f.attach
+		}
+
+		part, err := mw.CreateFormFile(f.attach, name)
+		if err != nil {
+			return err
+		}
+
+		n, err := io.Copy(part, io.LimitReader(f.Reader, maxSize+1))
+		if err != nil {
+			return err
+		}
+
+		if n > maxSize {
+			return ErrFileTooLarge
+		}
+	}
+
+	return mw.Close()
+}
@@ -0,0 +1,220 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestBot returns a bot calling handle for every request. handle returns
+// the result of the call, or an *APIError to fail it.
+func newTestBot(t *testing.T, handle func(r *http.Request) interface{}, options ...botOpt) *Bot {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := handle(r)
+		w.Header().Set("Content-Type", "application/json")
+		if apiErr, ok := response.(*APIError); ok {
+			w.WriteHeader(apiErr.ErrorCode)
+			json.NewEncoder(w).Encode(&ApiResult{ErrorCode: apiErr.ErrorCode, Description: apiErr.Description, Parameters: apiErr.Parameters})
+			return
+		}
+
+		result, err := json.Marshal(response)
+		if err != nil {
+			t.Error(err)
+		}
+
+		w.Write([]byte(`{"ok":true,"result":` + string(result) + `}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	bot := NewBot("123:TEST", append([]botOpt{WithServer(srv.URL)}, options...)...)
+	bot.HTTPClient = srv.Client()
+	return bot
+}
+
+func readPart(t *testing.T, r *http.Request, name string) string {
+	t.Helper()
+
+	if values := r.MultipartForm.Value[name]; len(values) > 0 {
+		return values[0]
+	}
+
+	files := r.MultipartForm.File[name]
+	if len(files) == 0 {
+		t.Fatalf("no part %q", name)
+	}
+
+	f, err := files[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestMultipartTopLevelFile(t *testing.T) {
+	var form map[string]string
+	var filename string
+	bot := newTestBot(t, func(r *http.Request) interface{} {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+
+		filename = r.MultipartForm.File["document"][0].Filename
+		form = map[string]string{
+			"chat_id":  readPart(t, r, "chat_id"),
+			"caption":  readPart(t, r, "caption"),
+			"document": readPart(t, r, "document"),
+		}
+		return &Message{MessageId: 1}
+	})
+
+	_, err := bot.SendDocumentCtx(context.Background(), &SendDocumentRequest{
+		ChatId:   42,
+		Document: FileReader("report.txt", strings.NewReader("contents")),
+		Caption:  "a \"quoted\" caption",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"chat_id":  "42",
+		"caption":  `a "quoted" caption`,
+		"document": "contents",
+	}
+	for key, value := range want {
+		if form[key] != value {
+			t.Errorf("%s = %q, want %q", key, form[key], value)
+		}
+	}
+
+	if filename != "report.txt" {
+		t.Errorf("filename = %q, want report.txt", filename)
+	}
+}
+
+func TestMultipartNestedAttach(t *testing.T) {
+	var media []map[string]interface{}
+	var files map[string]string
+	bot := newTestBot(t, func(r *http.Request) interface{} {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := json.Unmarshal([]byte(readPart(t, r, "media")), &media); err != nil {
+			t.Fatal(err)
+		}
+
+		files = map[string]string{}
+		for name := range r.MultipartForm.File {
+			files[name] = readPart(t, r, name)
+		}
+		return []interface{}{}
+	})
+
+	_, err := bot.SendMediaGroupCtx(context.Background(), &SendMediaGroupRequest{
+		ChatId: 42,
+		Media: []InputMedia{
+			&InputMediaPhoto{Type: "photo", Media: FileReader("a.jpg", strings.NewReader("first"))},
+			&InputMediaPhoto{Type: "photo", Media: FileID("existing")},
+			&InputMediaPhoto{Type: "photo", Media: FileReader("", strings.NewReader("second"))},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantMedia := []string{"attach://file0", "existing", "attach://file1"}
+	if len(media) != len(wantMedia) {
+		t.Fatalf("got %d media, want %d", len(media), len(wantMedia))
+	}
+
+	for i, want := range wantMedia {
+		if media[i]["media"] != want {
+			t.Errorf("media[%d] = %v, want %s", i, media[i]["media"], want)
+		}
+	}
+
+	if len(files) != 2 || files["file0"] != "first" || files["file1"] != "second" {
+		t.Errorf("files = %v", files)
+	}
+}
+
+func TestInputFileWithoutMultipart(t *testing.T) {
+	_, err := json.Marshal(FileReader("a.txt", strings.NewReader("")))
+	if err == nil || !strings.Contains(err.Error(), ErrInputFileNotAttached.Error()) {
+		t.Errorf("err = %v, want %v", err, ErrInputFileNotAttached)
+	}
+}
+
+func TestCollectUploadsSkipsNonUploads(t *testing.T) {
+	files := collectUploads(&SendMediaGroupRequest{
+		Media: []InputMedia{
+			&InputMediaPhoto{Media: FileURL("https://example.com/a.jpg")},
+			&InputMediaPhoto{Media: FileID("id")},
+		},
+	})
+
+	if len(files) != 0 {
+		t.Errorf("got %d uploads, want none", len(files))
+	}
+}
+
+func TestMultipartMaxSize(t *testing.T) {
+	bot := NewBot("123:TEST")
+	for size, want := range map[int64]error{8: nil, 7: ErrFileTooLarge} {
+		request := &SendDocumentRequest{ChatId: 1, Document: FileReader("a.txt", strings.NewReader("contents"))}
+		body, _, err := bot.encodeMultipart(request, collectUploads(request), size)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := io.ReadAll(body); !errors.Is(err, want) {
+			t.Errorf("limit %d: err = %v, want %v", size, err, want)
+		}
+	}
+}
+
+func TestLocalFile(t *testing.T) {
+	var body map[string]interface{}
+	bot := newTestBot(t, func(r *http.Request) interface{} {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type %s, a local file is not uploaded", r.Header.Get("Content-Type"))
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		return &Message{MessageId: 1}
+	})
+
+	abs, err := filepath.Abs("report.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// relative paths are resolved against the working directory
+	_, err = bot.SendDocumentCtx(context.Background(), &SendDocumentRequest{ChatId: 1, Document: LocalFile("report.pdf")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "file://" + filepath.ToSlash(abs); body["document"] != want {
+		t.Errorf("document = %v, want %s", body["document"], want)
+	}
+}
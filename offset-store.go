@@ -0,0 +1,96 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OffsetStore persists the offset of the next update to process, so a
+// restarted Poller continues where the previous one stopped.
+type OffsetStore interface {
+	LoadOffset(ctx context.Context) (int64, error)
+	SaveOffset(ctx context.Context, offset int64) error
+}
+
+type MemoryOffsetStore struct {
+	mu     sync.Mutex
+	offset int64
+}
+
+func NewMemoryOffsetStore() *MemoryOffsetStore {
+	return &MemoryOffsetStore{}
+}
+
+func (s *MemoryOffsetStore) LoadOffset(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offset, nil
+}
+
+func (s *MemoryOffsetStore) SaveOffset(ctx context.Context, offset int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset = offset
+	return nil
+}
+
+// FileOffsetStore keeps the offset in a text file. The file is replaced
+// atomically, so a crash never leaves a partially written offset.
+type FileOffsetStore struct {
+	Path string
+}
+
+func NewFileOffsetStore(path string) *FileOffsetStore {
+	return &FileOffsetStore{Path: path}
+}
+
+func (s *FileOffsetStore) LoadOffset(ctx context.Context) (int64, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+func (s *FileOffsetStore) SaveOffset(ctx context.Context, offset int64) error {
+	return writeFileAtomic(s.Path, []byte(strconv.FormatInt(offset, 10)))
+}
+
+// writeFileAtomic writes a temporary file next to path and renames it, so
+// readers see either the old or the new contents.
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+
+	if err != nil {
+		os.Remove(file.Name())
+	}
+
+	return err
+}
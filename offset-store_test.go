@@ -0,0 +1,50 @@
+package telegram
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileOffsetStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileOffsetStore(filepath.Join(t.TempDir(), "offset"))
+
+	if offset, err := store.LoadOffset(ctx); err != nil || offset != 0 {
+		t.Fatalf("LoadOffset() = %d, %v; want 0 without a file", offset, err)
+	}
+
+	for _, want := range []int64{42, 7} {
+		if err := store.SaveOffset(ctx, want); err != nil {
+			t.Fatal(err)
+		}
+
+		if offset, err := store.LoadOffset(ctx); err != nil || offset != want {
+			t.Errorf("LoadOffset() = %d, %v; want %d", offset, err, want)
+		}
+	}
+
+	assertFiles(t, filepath.Dir(store.Path), "offset")
+}
+
+// assertFiles checks that dir contains only the named files, so no temporary
+// files are left.
+func assertFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != len(names) {
+		t.Fatalf("%d files in %s, want %v", len(entries), dir, names)
+	}
+
+	for i, entry := range entries {
+		if entry.Name() != names[i] {
+			t.Errorf("file %s, want %s", entry.Name(), names[i])
+		}
+	}
+}
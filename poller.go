@@ -0,0 +1,404 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrPollerRunning = errors.New("telegram: poller is already running")
+
+// Poller receives updates with long polling. Transient errors (network, 5xx,
+// flood control) are retried with backoff, fatal ones (invalid token, conflict
+// with a webhook or another poller) stop it. When stopped, the offset of the
+// last update received from Updates is confirmed to Telegram, so a restarted
+// poller continues right after it. Updates which can't be decoded are skipped
+// and reported to OnError as *UpdateDecodeError.
+//
+// With an OffsetStore (WithOffsetStore) delivery is at-least-once: every
+// update must be passed to Ack once processed, the offset is stored and
+// confirmed to Telegram only when all updates of a batch are acknowledged.
+type Poller struct {
+	bot     *Bot
+	client  *http.Client
+	store   OffsetStore
+	request GetUpdatesRequest
+
+	// MinBackoff and MaxBackoff limit the delay before reconnecting after a
+	// transient error; default 500ms and 30s
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// OnError is called with transient errors before reconnecting, and with
+	// skipped updates
+	OnError func(err error)
+
+	mu      sync.Mutex
+	running bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+	updates chan *Update
+	err     error
+	pending map[int64]struct{}
+	acked   chan struct{}
+}
+
+// NewPoller creates a poller, it accepts the same options as PollUpdates
+// except WithContext; the context is passed to Start instead.
+func (b *Bot) NewPoller(options ...pollOpt) *Poller {
+	opt := &pollOptions{
+		timeout: 30,
+	}
+
+	for _, fn := range options {
+		fn(opt)
+	}
+
+	client := opt.client
+	if client == nil {
+		client = b.HTTPClient
+	}
+
+	done := make(chan struct{})
+	close(done)
+
+	return &Poller{
+		bot:    b,
+		client: client,
+		store:  opt.offsetStore,
+		request: GetUpdatesRequest{
+			Offset:         opt.offset,
+			Limit:          opt.limit,
+			Timeout:        opt.timeout,
+			AllowedUpdates: opt.allowedUpdates,
+		},
+		done:    done,
+		updates: make(chan *Update),
+		pending: map[int64]struct{}{},
+		acked:   make(chan struct{}, 1),
+	}
+}
+
+// Start begins polling until Stop is called, ctx is done or a fatal error
+// occurs. A stopped poller can be started again, it resumes from the last
+// received update and creates a new Updates channel.
+func (p *Poller) Start(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.running {
+		return ErrPollerRunning
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p.running = true
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	p.updates = make(chan *Update)
+	p.err = nil
+
+	go p.run(ctx, p.updates, p.done)
+	return nil
+}
+
+// Updates returns the channel of the current run. It is closed when the
+// poller stops.
+func (p *Poller) Updates() <-chan *Update {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.updates
+}
+
+// Stop stops polling and waits until the last received offset is confirmed.
+func (p *Poller) Stop() error {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+
+	<-done
+	return p.Err()
+}
+
+// Wait blocks until the poller stops and returns the fatal error, if any.
+func (p *Poller) Wait() error {
+	p.mu.Lock()
+	done := p.done
+	p.mu.Unlock()
+
+	<-done
+	return p.Err()
+}
+
+// Err returns the error that stopped the poller.
+func (p *Poller) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// Offset returns the offset of the next update to process: the lowest
+// unacknowledged update when using an OffsetStore.
+func (p *Poller) Offset() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.offset()
+}
+
+func (p *Poller) offset() int64 {
+	offset := p.request.Offset
+	for id := range p.pending {
+		if id < offset {
+			offset = id
+		}
+	}
+
+	return offset
+}
+
+// Ack marks the update as processed. It is required when the poller uses an
+// OffsetStore and does nothing otherwise.
+func (p *Poller) Ack(update *Update) {
+	p.mu.Lock()
+	_, ok := p.pending[update.UpdateId]
+	delete(p.pending, update.UpdateId)
+	p.mu.Unlock()
+
+	if ok {
+		select {
+		case p.acked <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// UpdateDecodeError is an update which can't be decoded. The poller skips it,
+// or stops if even its update_id can't be read (UpdateID is zero then): it
+// would get the same update again and again.
+type UpdateDecodeError struct {
+	UpdateID int64
+	Data     json.RawMessage
+	Err      error
+}
+
+func (e *UpdateDecodeError) Error() string {
+	if e.UpdateID == 0 {
+		return fmt.Sprintf("telegram: can't decode updates: %v", e.Err)
+	}
+
+	return fmt.Sprintf("telegram: can't decode update %d: %v", e.UpdateID, e.Err)
+}
+
+func (e *UpdateDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsFatalPollError reports whether getUpdates can't succeed by retrying: the
+// token is invalid, a webhook is set or another poller is running.
+func IsFatalPollError(err error) bool {
+	apiErr := asAPIError(err)
+	return apiErr != nil && apiErr.ErrorCode >= 400 && apiErr.ErrorCode < 500 && apiErr.ErrorCode != 429
+}
+
+func (p *Poller) run(ctx context.Context, channel chan<- *Update, done chan<- struct{}) {
+	err := p.load(ctx)
+	initial := p.Offset()
+
+	if err == nil {
+		err = p.poll(ctx, channel)
+	}
+
+	if err == nil && p.Offset() != initial {
+		err = p.save()
+		p.acknowledge()
+	}
+
+	p.mu.Lock()
+	p.err = err
+	p.running = false
+	p.cancel()
+	p.mu.Unlock()
+
+	close(channel)
+	close(done)
+}
+
+func (p *Poller) poll(ctx context.Context, channel chan<- *Update) error {
+	for attempt := 0; ; {
+		p.mu.Lock()
+		request := p.request
+		request.Offset = p.offset()
+		p.mu.Unlock()
+
+		var result json.RawMessage
+		err := p.getUpdates(ctx, &request, &result)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			if IsFatalPollError(err) {
+				return err
+			}
+
+			if p.OnError != nil {
+				p.OnError(err)
+			}
+
+			delay := backoff(p.MinBackoff, p.MaxBackoff, attempt)
+			if apiErr := asAPIError(err); apiErr != nil && apiErr.RetryAfter() > 0 {
+				delay = apiErr.RetryAfter()
+			}
+
+			attempt++
+			if sleep(ctx, delay) != nil {
+				return nil
+			}
+			continue
+		}
+
+		// decoded one by one, so an update which can't be decoded doesn't fail
+		// the whole batch
+		var batch []json.RawMessage
+		if err := p.bot.JSONUnmarshal(result, &batch); err != nil {
+			return &UpdateDecodeError{Data: result, Err: err}
+		}
+
+		attempt = 0
+		for _, data := range batch {
+			update := &Update{}
+			if err := p.bot.JSONUnmarshal(data, update); err != nil {
+				decodeErr := &UpdateDecodeError{Data: data, Err: err}
+				var probe struct {
+					UpdateId int64 `json:"update_id"`
+				}
+				if json.Unmarshal(data, &probe) != nil || probe.UpdateId == 0 {
+					return decodeErr
+				}
+
+				decodeErr.UpdateID = probe.UpdateId
+				if p.OnError != nil {
+					p.OnError(decodeErr)
+				}
+
+				p.advance(probe.UpdateId)
+				continue
+			}
+
+			// added before sending, so an early Ack isn't lost
+			p.mu.Lock()
+			if p.store != nil {
+				p.pending[update.UpdateId] = struct{}{}
+			}
+			p.mu.Unlock()
+
+			select {
+			case channel <- update:
+			case <-ctx.Done():
+				p.mu.Lock()
+				delete(p.pending, update.UpdateId)
+				p.mu.Unlock()
+				return nil
+			}
+
+			p.advance(update.UpdateId)
+		}
+
+		if p.store != nil {
+			if !p.waitAcks(ctx) {
+				return nil
+			}
+
+			if err := p.save(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// advance moves the offset past the update.
+func (p *Poller) advance(updateID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if updateID >= p.request.Offset {
+		p.request.Offset = updateID + 1
+	}
+}
+
+// waitAcks waits until all delivered updates are acknowledged.
+func (p *Poller) waitAcks(ctx context.Context) bool {
+	for {
+		p.mu.Lock()
+		pending := len(p.pending)
+		p.mu.Unlock()
+
+		if pending == 0 {
+			return true
+		}
+
+		select {
+		case <-p.acked:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (p *Poller) load(ctx context.Context) error {
+	if p.store == nil {
+		return nil
+	}
+
+	offset, err := p.store.LoadOffset(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	if offset > p.request.Offset {
+		p.request.Offset = offset
+	}
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *Poller) save() error {
+	if p.store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return p.store.SaveOffset(ctx, p.Offset())
+}
+
+// acknowledge confirms received updates, so Telegram doesn't send them again.
+func (p *Poller) acknowledge() {
+	p.mu.Lock()
+	request := GetUpdatesRequest{
+		Offset:         p.offset(),
+		Limit:          1,
+		AllowedUpdates: p.request.AllowedUpdates,
+	}
+	p.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var result json.RawMessage
+	p.getUpdates(ctx, &request, &result)
+}
+
+func (p *Poller) getUpdates(ctx context.Context, request *GetUpdatesRequest, result *json.RawMessage) error {
+	return p.bot.wrap(func(ctx context.Context, method string, request interface{}, result interface{}) error {
+		return p.bot.doRequest(ctx, p.client.Do, method, request, result)
+	})(ctx, "getUpdates", request, result)
+}
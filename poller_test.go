@@ -0,0 +1,279 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePoll answers getUpdates with queued responses, keyed by the requested
+// offset; a response can also be a bare status code. Offsets without a
+// response get an empty result.
+type fakePoll struct {
+	mu        sync.Mutex
+	responses map[int64][]string
+	offsets   []int64
+}
+
+func newPollBot(t *testing.T, responses map[int64][]string) (*Bot, *fakePoll) {
+	t.Helper()
+
+	fake := &fakePoll{responses: responses}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request GetUpdatesRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Error(err)
+		}
+
+		fake.mu.Lock()
+		fake.offsets = append(fake.offsets, request.Offset)
+		queue := fake.responses[request.Offset]
+		response := `{"ok":true,"result":[]}`
+		if len(queue) > 0 {
+			response = queue[0]
+			fake.responses[request.Offset] = queue[1:]
+		}
+		fake.mu.Unlock()
+
+		if response == `{"ok":true,"result":[]}` {
+			// a short long poll
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if status, err := strconv.Atoi(response); err == nil {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	bot := NewBot("123:TEST", WithServer(srv.URL))
+	bot.HTTPClient = srv.Client()
+	return bot, fake
+}
+
+func (f *fakePoll) requested(offset int64) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, o := range f.offsets {
+		if o == offset {
+			return true
+		}
+	}
+
+	return false
+}
+
+func receive(t *testing.T, updates <-chan *Update) *Update {
+	t.Helper()
+
+	select {
+	case update := <-updates:
+		return update
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update received")
+		return nil
+	}
+}
+
+func TestPollerDoesNotReuseUpdates(t *testing.T) {
+	bot, _ := newPollBot(t, map[int64][]string{
+		0: {`{"ok":true,"result":[{"update_id":1,"message":{"message_id":1,"text":"first","chat":{"id":1,"type":"private"}}}]}`},
+		2: {`{"ok":true,"result":[{"update_id":2,"callback_query":{"id":"q","data":"second"}}]}`},
+	})
+
+	poller := bot.NewPoller()
+	if err := poller.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer poller.Stop()
+
+	first := receive(t, poller.Updates())
+	second := receive(t, poller.Updates())
+
+	if first == second {
+		t.Fatal("the second update is the first one decoded again")
+	}
+
+	if first.UpdateId != 1 || first.Message == nil || first.Message.Text != "first" || first.CallbackQuery != nil {
+		t.Errorf("first update changed: %+v", first)
+	}
+
+	if second.UpdateId != 2 || second.Message != nil || second.CallbackQuery == nil {
+		t.Errorf("second update = %+v", second)
+	}
+}
+
+func TestPollerSkipsUndecodableUpdates(t *testing.T) {
+	bot, fake := newPollBot(t, map[int64][]string{
+		0: {`{"ok":true,"result":[{"update_id":1,"message":{"message_id":"not a number"}},{"update_id":2,"message":{"message_id":2,"chat":{"id":1,"type":"private"}}}]}`},
+		3: {`{"ok":true,"result":[{"update_id":3,"message":"broken"}]}`},
+	})
+
+	var mu sync.Mutex
+	var reported []int64
+	poller := bot.NewPoller()
+	poller.OnError = func(err error) {
+		var decodeErr *UpdateDecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Errorf("unexpected error %v", err)
+			return
+		}
+
+		mu.Lock()
+		reported = append(reported, decodeErr.UpdateID)
+		mu.Unlock()
+	}
+
+	if err := poller.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if update := receive(t, poller.Updates()); update.UpdateId != 2 {
+		t.Errorf("got update %d, want 2", update.UpdateId)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !fake.requested(4) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := poller.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 2 || reported[0] != 1 || reported[1] != 3 {
+		t.Errorf("reported %v, want [1 3]", reported)
+	}
+
+	if !fake.requested(4) {
+		t.Error("the poller didn't continue after the undecodable update")
+	}
+}
+
+func TestPollerStopsOnUndecodableBatch(t *testing.T) {
+	bot, _ := newPollBot(t, map[int64][]string{
+		0: {`{"ok":true,"result":{"update_id":1}}`},
+	})
+
+	poller := bot.NewPoller()
+	if err := poller.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var decodeErr *UpdateDecodeError
+	if err := poller.Wait(); !errors.As(err, &decodeErr) {
+		t.Errorf("err = %v, want *UpdateDecodeError", err)
+	}
+}
+
+func TestPollerRetriesTransientErrors(t *testing.T) {
+	bot, _ := newPollBot(t, map[int64][]string{
+		0: {"502", `{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":0}}`, `{"ok":true,"result":[{"update_id":1}]}`},
+		2: {`{"ok":false,"error_code":401,"description":"Unauthorized"}`},
+	})
+
+	var mu sync.Mutex
+	errs := 0
+	poller := bot.NewPoller()
+	poller.MinBackoff = time.Millisecond
+	poller.MaxBackoff = time.Millisecond
+	poller.OnError = func(err error) {
+		mu.Lock()
+		errs++
+		mu.Unlock()
+	}
+
+	if err := poller.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if update := receive(t, poller.Updates()); update.UpdateId != 1 {
+		t.Errorf("got update %d, want 1", update.UpdateId)
+	}
+
+	err := poller.Wait()
+	if !IsFatalPollError(err) {
+		t.Errorf("err = %v, want a fatal error", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if errs != 2 {
+		t.Errorf("%d transient errors reported, want 2", errs)
+	}
+}
+
+func TestPollerRestart(t *testing.T) {
+	bot, fake := newPollBot(t, map[int64][]string{
+		0: {`{"ok":true,"result":[{"update_id":5}]}`},
+	})
+
+	poller := bot.NewPoller()
+	for i := 0; i < 2; i++ {
+		if err := poller.Start(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 0 {
+			receive(t, poller.Updates())
+		}
+
+		if err := poller.Stop(); err != nil {
+			t.Fatal(err)
+		}
+
+		// the channel of a stopped poller is closed
+		if _, ok := <-poller.Updates(); ok {
+			t.Error("update received after Stop")
+		}
+	}
+
+	if poller.Offset() != 6 || !fake.requested(6) {
+		t.Errorf("offset = %d, want 6 confirmed to the server", poller.Offset())
+	}
+}
+
+func TestPollUpdatesWithOffsetStore(t *testing.T) {
+	bot, _ := newPollBot(t, map[int64][]string{
+		10: {`{"ok":true,"result":[{"update_id":10},{"update_id":11}]}`},
+		12: {`{"ok":true,"result":[{"update_id":12}]}`},
+	})
+
+	store := NewMemoryOffsetStore()
+	store.SaveOffset(context.Background(), 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := bot.PollUpdates(WithContext(ctx), WithOffsetStore(store))
+	for _, want := range []int64{10, 11, 12} {
+		update := receive(t, updates)
+		if update.UpdateId != want {
+			t.Fatalf("got update %d, want %d", update.UpdateId, want)
+		}
+
+		// the next batch is requested only after the whole batch is acknowledged
+		bot.Ack(update)
+	}
+
+	cancel()
+	for range updates {
+	}
+
+	if offset, _ := store.LoadOffset(context.Background()); offset != 13 {
+		t.Errorf("stored offset = %d, want 13", offset)
+	}
+}
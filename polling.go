@@ -11,12 +11,13 @@ type pollOptions struct {
 	offset         int64
 	timeout        int
 	limit          int
-	allowedUpdates []string
+	allowedUpdates []UpdateType
+	offsetStore    OffsetStore
 }
 
 type pollOpt func(*pollOptions)
 
-func WithAllowedUpdates(updates ...string) pollOpt {
+func WithAllowedUpdates(updates ...UpdateType) pollOpt {
 	return func(po *pollOptions) {
 		po.allowedUpdates = updates
 	}
@@ -52,60 +53,45 @@ func WithClient(client *http.Client) pollOpt {
 	}
 }
 
-func (b *Bot) PollUpdates(options ...pollOpt) <-chan *Update {
-	opt := &pollOptions{
-		timeout: 30,
+// WithOffsetStore makes a Poller load the offset on start and store it after
+// updates are acknowledged with Poller.Ack, or Bot.Ack for PollUpdates.
+func WithOffsetStore(store OffsetStore) pollOpt {
+	return func(po *pollOptions) {
+		po.offsetStore = store
 	}
+}
 
+// PollUpdates starts a Poller and returns its updates. The channel is closed
+// when the context passed with WithContext is done or a fatal error occurs,
+// see PollError. With WithOffsetStore every update must be passed to Ack.
+func (b *Bot) PollUpdates(options ...pollOpt) <-chan *Update {
+	opt := &pollOptions{}
 	for _, fn := range options {
 		fn(opt)
 	}
 
-	b.pollError = nil
-	channel := make(chan *Update, 1)
-	request := &GetUpdatesRequest{
-		Offset:         opt.offset,
-		Limit:          opt.limit,
-		Timeout:        opt.timeout,
-		AllowedUpdates: opt.allowedUpdates,
-	}
-
-	updates := make([]*Update, 0)
-
-	doRequest := func(req *http.Request) (*http.Response, error) {
-		client := opt.client
-		if client == nil {
-			client = b.HTTPClient
-		}
-
-		if opt.context != nil {
-			req = req.WithContext(opt.context)
-		}
-
-		return client.Do(req)
+	ctx := opt.context
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	go func() {
-		for {
-			err := b.doRequest(doRequest, "getUpdates", request, &updates)
+	poller := b.NewPoller(options...)
+	poller.Start(ctx)
 
-			if err != nil {
-				b.pollError = err
-				close(channel)
-				return
-			}
+	b.pollMu.Lock()
+	b.poller = poller
+	b.pollMu.Unlock()
 
-			for _, update := range updates {
-				channel <- update
-
-				if update.UpdateId >= request.Offset {
-					request.Offset = update.UpdateId + 1
-				}
-			}
+	return poller.Updates()
+}
 
-			updates = updates[:0]
-		}
-	}()
+// Ack acknowledges an update received from PollUpdates, see Poller.Ack.
+func (b *Bot) Ack(update *Update) {
+	b.pollMu.Lock()
+	poller := b.poller
+	b.pollMu.Unlock()
 
-	return channel
+	if poller != nil {
+		poller.Ack(update)
+	}
 }
@@ -0,0 +1,247 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limiter is called before every API call made by Bot (see Bot.Limiter).
+// chatID is the ChatId of the request, nil if the request has none.
+type Limiter interface {
+	Wait(ctx context.Context, method string, chatID interface{}) error
+}
+
+// LimiterStats describes the time calls spent waiting in a RateLimiter.
+type LimiterStats struct {
+	// Calls is the number of limited calls
+	Calls int64
+	// Delayed is the number of calls which had to wait
+	Delayed int64
+	// Waiting is the number of calls waiting right now
+	Waiting   int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// RateLimiter spaces out calls sending or editing messages to stay within
+// Telegram limits: about 30 messages per second overall, 1 message per second
+// in a private chat and 20 messages per minute in a group. Calls are served in
+// the order they arrive, first per chat and then globally.
+type RateLimiter struct {
+	// Minimal intervals between calls
+	Global      time.Duration
+	PrivateChat time.Duration
+	GroupChat   time.Duration
+
+	mu        sync.Mutex
+	global    schedule
+	chats     map[string]*schedule
+	cleanupAt int
+	stats     LimiterStats
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		Global:      time.Second / 30,
+		PrivateChat: time.Second,
+		GroupChat:   time.Minute / 20,
+		chats:       map[string]*schedule{},
+	}
+}
+
+// schedule hands out times spaced by an interval. Times given back by
+// cancelled calls are handed out again, so they don't delay later calls.
+type schedule struct {
+	next time.Time
+	// free is sorted
+	free []time.Time
+}
+
+func (s *schedule) reserve(now time.Time, interval time.Duration) time.Time {
+	for len(s.free) > 0 {
+		at := s.free[0]
+		s.free = s.free[1:]
+		if !at.Before(now) {
+			return at
+		}
+	}
+
+	at := maxTime(now, s.next)
+	s.next = at.Add(interval)
+	return at
+}
+
+func (s *schedule) release(at time.Time, interval time.Duration) {
+	if !at.Add(interval).Equal(s.next) {
+		i := sort.Search(len(s.free), func(i int) bool { return s.free[i].After(at) })
+		s.free = append(s.free, time.Time{})
+		copy(s.free[i+1:], s.free[i:])
+		s.free[i] = at
+		return
+	}
+
+	s.next = at
+	for n := len(s.free); n > 0 && s.free[n-1].Add(interval).Equal(s.next); n-- {
+		s.next = s.free[n-1]
+		s.free = s.free[:n-1]
+	}
+}
+
+var limitedPrefixes = []string{"send", "forward", "copy", "edit"}
+
+func isLimited(method string) bool {
+	for _, prefix := range limitedPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isGroup reports whether chatID identifies a group or a channel: their ids
+// are negative, and they are the only chats addressable by @username.
+func isGroup(chatID interface{}) bool {
+	switch id := chatID.(type) {
+	case int:
+		return id < 0
+	case int64:
+		return id < 0
+	case string:
+		return strings.HasPrefix(id, "@") || strings.HasPrefix(id, "-")
+	}
+
+	return false
+}
+
+func (l *RateLimiter) Wait(ctx context.Context, method string, chatID interface{}) error {
+	if !isLimited(method) {
+		return nil
+	}
+
+	start := time.Now()
+
+	l.mu.Lock()
+	l.stats.Calls++
+	l.stats.Waiting++
+
+	var chat *schedule
+	var chatAt time.Time
+	interval := l.PrivateChat
+	if chatID != nil {
+		if isGroup(chatID) {
+			interval = l.GroupChat
+		}
+
+		l.cleanup(start)
+		key := fmt.Sprint(chatID)
+		chat = l.chats[key]
+		if chat == nil {
+			chat = &schedule{}
+			l.chats[key] = chat
+		}
+
+		chatAt = chat.reserve(start, interval)
+		l.mu.Unlock()
+
+		if err := sleep(ctx, time.Until(chatAt)); err != nil {
+			l.mu.Lock()
+			chat.release(chatAt, interval)
+			l.mu.Unlock()
+
+			l.done(start)
+			return err
+		}
+
+		l.mu.Lock()
+	}
+
+	at := l.global.reserve(time.Now(), l.Global)
+	l.mu.Unlock()
+
+	err := sleep(ctx, time.Until(at))
+	if err != nil {
+		// the call isn't made, so neither of the slots is used
+		l.mu.Lock()
+		l.global.release(at, l.Global)
+		if chat != nil {
+			chat.release(chatAt, interval)
+		}
+		l.mu.Unlock()
+	}
+
+	l.done(start)
+	return err
+}
+
+func (l *RateLimiter) done(start time.Time) {
+	wait := time.Since(start)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Waiting--
+	if wait > time.Millisecond {
+		l.stats.Delayed++
+	}
+
+	l.stats.TotalWait += wait
+	if wait > l.stats.MaxWait {
+		l.stats.MaxWait = wait
+	}
+}
+
+// cleanup forgets chats which can send right away, so the map doesn't grow
+// with every chat the bot has ever written to. The map is scanned when its
+// size doubles, so the cost is amortized over the calls.
+func (l *RateLimiter) cleanup(now time.Time) {
+	if l.chats == nil {
+		l.chats = map[string]*schedule{}
+	}
+
+	if len(l.chats) < l.cleanupAt || len(l.chats) < 1024 {
+		return
+	}
+
+	for key, chat := range l.chats {
+		if chat.next.Before(now) {
+			delete(l.chats, key)
+		}
+	}
+
+	l.cleanupAt = 2 * len(l.chats)
+}
+
+func (l *RateLimiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+// requestChatID returns the ChatId field of a request.
+func requestChatID(request interface{}) interface{} {
+	v := reflect.ValueOf(request)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	field := v.Elem().FieldByName("ChatId")
+	if !field.IsValid() || field.IsZero() {
+		return nil
+	}
+
+	return field.Interface()
+}
@@ -0,0 +1,142 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSpacing(t *testing.T) {
+	l := NewRateLimiter()
+	l.Global = 20 * time.Millisecond
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(context.Background(), "sendMessage", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 4*l.Global {
+		t.Errorf("5 calls took %v, want at least %v", elapsed, 4*l.Global)
+	}
+
+	if stats := l.Stats(); stats.Calls != 5 || stats.Waiting != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	if err := l.Wait(context.Background(), "getMe", nil); err != nil {
+		t.Error(err)
+	}
+
+	if stats := l.Stats(); stats.Calls != 5 {
+		t.Errorf("getMe was limited, stats = %+v", stats)
+	}
+}
+
+func TestRateLimiterPerChat(t *testing.T) {
+	l := NewRateLimiter()
+	l.Global = 0
+	l.PrivateChat = 50 * time.Millisecond
+
+	start := time.Now()
+	for _, chatID := range []interface{}{int64(1), int64(2), int64(1)} {
+		if err := l.Wait(context.Background(), "sendMessage", chatID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < l.PrivateChat {
+		t.Errorf("second call to chat 1 after %v, want at least %v", elapsed, l.PrivateChat)
+	}
+}
+
+func TestRateLimiterCancelReleasesSlots(t *testing.T) {
+	l := NewRateLimiter()
+	l.Global = 50 * time.Millisecond
+
+	// the first call takes the current slot, the next ones wait
+	if err := l.Wait(context.Background(), "sendMessage", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(ctx, "sendMessage", nil); err == nil {
+				t.Error("cancelled call succeeded")
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	start := time.Now()
+	if err := l.Wait(context.Background(), "sendMessage", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*l.Global {
+		t.Errorf("call after cancelled ones waited %v, want at most %v", elapsed, 2*l.Global)
+	}
+}
+
+func TestRateLimiterCleanup(t *testing.T) {
+	l := NewRateLimiter()
+	l.Global = 0
+	l.PrivateChat = time.Millisecond
+
+	for i := 0; i < 1024; i++ {
+		if err := l.Wait(context.Background(), "sendMessage", int64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	if err := l.Wait(context.Background(), "sendMessage", int64(-1)); err != nil {
+		t.Fatal(err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.chats) != 1 {
+		t.Errorf("%d chats left after cleanup, want 1", len(l.chats))
+	}
+}
+
+func TestScheduleRelease(t *testing.T) {
+	now := time.Now()
+	interval := time.Second
+	s := &schedule{}
+
+	slots := []time.Time{}
+	for i := 0; i < 3; i++ {
+		slots = append(slots, s.reserve(now, interval))
+	}
+
+	// a slot in the middle is handed out again
+	s.release(slots[1], interval)
+	if at := s.reserve(now, interval); !at.Equal(slots[1]) {
+		t.Errorf("reserved %v, want the released %v", at.Sub(now), slots[1].Sub(now))
+	}
+
+	// released in any order, the schedule is back at the start
+	s.release(slots[1], interval)
+	s.release(slots[2], interval)
+	s.release(slots[0], interval)
+	if !s.next.Equal(slots[0]) || len(s.free) != 0 {
+		t.Errorf("next = %v, free = %v, want next = 0 and nothing free", s.next.Sub(now), s.free)
+	}
+}
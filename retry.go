@@ -0,0 +1,145 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of failed API calls, see
+// Bot.RetryPolicy. Flood control errors (429) are retried after the
+// retry_after returned by Telegram, server (5xx) and network errors, and 429
+// without retry_after, are retried with jittered exponential backoff.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// MinBackoff and MaxBackoff limit the delay between retries of 5xx and
+	// network errors; default 500ms and 30s
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest retry_after to wait for; the error is
+	// returned if Telegram asks to wait longer. Zero means no limit
+	MaxRetryAfter time.Duration
+	// RetryNonIdempotent allows retrying methods like sendMessage after 5xx and
+	// network errors, when the request could have been processed already and a
+	// retry may produce a duplicate. 429 errors are always retried
+	RetryNonIdempotent bool
+	// Idempotent reports whether a method is safe to repeat; defaults to
+	// IsIdempotent
+	Idempotent func(method string) bool
+}
+
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxRetries:    3,
+	MinBackoff:    500 * time.Millisecond,
+	MaxBackoff:    30 * time.Second,
+	MaxRetryAfter: time.Minute,
+}
+
+var idempotentPrefixes = []string{
+	"get", "set", "delete", "edit", "pin", "unpin", "ban", "unban",
+	"restrict", "promote", "approve", "decline", "leave", "logOut",
+}
+
+// IsIdempotent reports whether repeating a call of method has the same effect
+// as calling it once.
+func IsIdempotent(method string) bool {
+	for _, prefix := range idempotentPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryDelay returns how long to wait before the next attempt, or false if
+// err should be returned.
+func (p *RetryPolicy) retryDelay(method string, attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxRetries {
+		return 0, false
+	}
+
+	if apiErr := asAPIError(err); apiErr != nil {
+		if apiErr.ErrorCode == 429 {
+			delay := apiErr.RetryAfter()
+			if delay == 0 {
+				return p.backoff(attempt), true
+			}
+
+			if p.MaxRetryAfter > 0 && delay > p.MaxRetryAfter {
+				return 0, false
+			}
+
+			return delay, true
+		}
+
+		if apiErr.ErrorCode < 500 {
+			return 0, false
+		}
+	} else if !isNetworkError(err) {
+		return 0, false
+	}
+
+	idempotent := p.Idempotent
+	if idempotent == nil {
+		idempotent = IsIdempotent
+	}
+
+	if !p.RetryNonIdempotent && !idempotent(method) {
+		return 0, false
+	}
+
+	return p.backoff(attempt), true
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	return backoff(p.MinBackoff, p.MaxBackoff, attempt)
+}
+
+// backoff returns a jittered exponential delay for the given attempt.
+func backoff(min, max time.Duration, attempt int) time.Duration {
+	if min <= 0 {
+		min = 500 * time.Millisecond
+	}
+
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+
+	if attempt > 30 {
+		attempt = 30
+	}
+
+	delay := min << attempt
+	if delay > max || delay <= 0 {
+		delay = max
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxRetries:    3,
+		MinBackoff:    100 * time.Millisecond,
+		MaxBackoff:    time.Second,
+		MaxRetryAfter: time.Minute,
+	}
+
+	retryAfter := func(seconds int) error {
+		return &APIError{ErrorCode: 429, Description: "Too Many Requests", Parameters: &ResponseParameters{RetryAfter: seconds}}
+	}
+	serverError := &APIError{ErrorCode: 502, Description: "Bad Gateway"}
+	networkError := &url.Error{Op: "Post", URL: "https://api.telegram.org", Err: &net.OpError{Op: "dial", Err: io.EOF}}
+
+	// backoff of attempt n is between MinBackoff<<n / 2 and MinBackoff<<n
+	for _, test := range []struct {
+		name     string
+		policy   *RetryPolicy
+		method   string
+		attempt  int
+		err      error
+		ok       bool
+		min, max time.Duration
+	}{
+		{name: "retry_after", method: "sendMessage", err: retryAfter(5), ok: true, min: 5 * time.Second, max: 5 * time.Second},
+		{name: "429 without retry_after", method: "sendMessage", attempt: 1, err: &APIError{ErrorCode: 429}, ok: true, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{name: "MaxRetryAfter exceeded", method: "sendMessage", err: retryAfter(120)},
+		{name: "MaxRetries", method: "getMe", attempt: 3, err: retryAfter(1)},
+		{name: "wrapped 429", method: "sendMessage", err: fmt.Errorf("send: %w", retryAfter(1)), ok: true, min: time.Second, max: time.Second},
+		{name: "4xx", method: "getMe", err: &APIError{ErrorCode: 400, Description: "Bad Request"}},
+		{name: "5xx idempotent", method: "getChat", attempt: 2, err: serverError, ok: true, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "5xx non-idempotent", method: "sendMessage", err: serverError},
+		{
+			name:    "5xx RetryNonIdempotent",
+			policy:  &RetryPolicy{MaxRetries: 3, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, RetryNonIdempotent: true},
+			method:  "sendMessage",
+			attempt: 2, err: serverError, ok: true, min: 200 * time.Millisecond, max: 400 * time.Millisecond,
+		},
+		{
+			name:   "custom Idempotent",
+			policy: &RetryPolicy{MaxRetries: 3, MinBackoff: 100 * time.Millisecond, Idempotent: func(method string) bool { return method == "sendMessage" }},
+			method: "sendMessage", err: serverError, ok: true, min: 50 * time.Millisecond, max: 100 * time.Millisecond,
+		},
+		{name: "MaxBackoff", method: "getChat", attempt: 2, policy: &RetryPolicy{MaxRetries: 3, MinBackoff: 100 * time.Millisecond, MaxBackoff: 150 * time.Millisecond}, err: serverError, ok: true, min: 75 * time.Millisecond, max: 150 * time.Millisecond},
+		{name: "network error", method: "getMe", err: networkError, ok: true, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "unexpected EOF", method: "getMe", err: io.ErrUnexpectedEOF, ok: true, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "network error non-idempotent", method: "sendMessage", err: networkError},
+		{name: "context canceled", method: "getMe", err: &url.Error{Op: "Post", URL: "https://api.telegram.org", Err: context.Canceled}},
+		{name: "deadline exceeded", method: "getMe", err: context.DeadlineExceeded},
+		{name: "other error", method: "getMe", err: fmt.Errorf("json: cannot unmarshal")},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			p := policy
+			if test.policy != nil {
+				p = test.policy
+			}
+
+			delay, ok := p.retryDelay(test.method, test.attempt, test.err)
+			if ok != test.ok {
+				t.Fatalf("retry = %v, want %v", ok, test.ok)
+			}
+
+			if ok && (delay < test.min || delay > test.max) {
+				t.Errorf("delay = %s, want between %s and %s", delay, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	for method, want := range map[string]bool{
+		"getUpdates":      true,
+		"editMessageText": true,
+		"deleteMessage":   true,
+		"sendMessage":     false,
+		"forwardMessage":  false,
+		"copyMessage":     false,
+	} {
+		if got := IsIdempotent(method); got != want {
+			t.Errorf("IsIdempotent(%s) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestCallRetries(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"ok":false,"error_code":502,"description":"Bad Gateway"}`))
+	}))
+	defer srv.Close()
+
+	bot := NewBot("123:TEST", WithServer(srv.URL))
+	bot.HTTPClient = srv.Client()
+	bot.RetryPolicy = &RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond, RetryNonIdempotent: true}
+	ctx := context.Background()
+
+	_, err := bot.SendMessageCtx(ctx, &SendMessageRequest{ChatId: 1, Text: "hi"})
+	if apiErr := asAPIError(err); apiErr == nil || apiErr.ErrorCode != 502 {
+		t.Errorf("err = %v, want 502", err)
+	}
+
+	if n := atomic.SwapInt32(&attempts, 0); n != 3 {
+		t.Errorf("sendMessage sent %d times, want 3", n)
+	}
+
+	// the reader is consumed by the first attempt, an upload is never repeated
+	_, err = bot.SendDocumentCtx(ctx, &SendDocumentRequest{
+		ChatId:   1,
+		Document: FileReader("a.txt", strings.NewReader("contents")),
+	})
+	if err == nil {
+		t.Error("sendDocument succeeded")
+	}
+
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("sendDocument sent %d times, want 1", n)
+	}
+}
@@ -0,0 +1,110 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+)
+
+// File size limits of the cloud Bot API server and of a local one, see
+// Bot.MaxDownloadSize and Bot.MaxUploadSize.
+const (
+	MaxDownloadSize    = 20 << 20
+	MaxUploadSize      = 50 << 20
+	LocalMaxUploadSize = 2000 << 20
+)
+
+type botOpt func(*Bot)
+
+// WithServer makes the bot use the Bot API server at url instead of BaseURL.
+func WithServer(url string) botOpt {
+	return func(b *Bot) {
+		b.server = strings.TrimSuffix(url, "/") + "/"
+	}
+}
+
+// WithLocalServer makes the bot use a Bot API server running in the --local
+// mode. Files are downloaded directly from disk, as File.FilePath is an
+// absolute path, so the bot must have access to the server's working
+// directory. Files can be uploaded with LocalFile.
+func WithLocalServer(url string) botOpt {
+	return func(b *Bot) {
+		WithServer(url)(b)
+		b.local = true
+	}
+}
+
+// WithTestEnvironment makes the bot use the Telegram test environment, which
+// has separate accounts and bots created with @BotFather of the test server.
+// API calls and file downloads are sent to /bot<token>/test/.
+func WithTestEnvironment() botOpt {
+	return func(b *Bot) {
+		b.test = true
+	}
+}
+
+// IsLocal reports whether the bot uses a local Bot API server.
+func (b *Bot) IsLocal() bool {
+	return b.local
+}
+
+// IsTest reports whether the bot uses the test environment.
+func (b *Bot) IsTest() bool {
+	return b.test
+}
+
+// MaxDownloadSize returns the size limit of files downloaded from the server,
+// zero means no limit. It is the default limit of SaveFile.
+func (b *Bot) MaxDownloadSize() int64 {
+	if b.local {
+		return 0
+	}
+
+	return MaxDownloadSize
+}
+
+// MaxUploadSize returns the size limit of uploaded files, a request with a
+// bigger file fails with ErrFileTooLarge.
+func (b *Bot) MaxUploadSize() int64 {
+	if b.local {
+		return LocalMaxUploadSize
+	}
+
+	return MaxUploadSize
+}
+
+// Migrate moves the bot to another Bot API server and returns a bot using it,
+// configured with options like NewBot and sharing the settings of b, including
+// the test environment. Updates must not be received while migrating.
+//
+// A bot on the cloud server is logged out with logOut; it can't log in back
+// to the cloud for 10 minutes. A bot on a local server has its webhook deleted,
+// so the server doesn't launch it again after a restart, and is closed with
+// close; Telegram returns error 429 in the first 10 minutes after the bot is
+// launched, see APIError.RetryAfter.
+func (b *Bot) Migrate(ctx context.Context, options ...botOpt) (*Bot, error) {
+	if b.local {
+		if _, err := b.DeleteWebhookCtx(ctx, &DeleteWebhookRequest{}); err != nil {
+			return nil, err
+		}
+
+		if _, err := b.CloseCtx(ctx); err != nil {
+			return nil, err
+		}
+	} else if _, err := b.LogOutCtx(ctx); err != nil {
+		return nil, err
+	}
+
+	// the environment is a property of the bot, not of the server
+	if b.test {
+		options = append([]botOpt{WithTestEnvironment()}, options...)
+	}
+
+	moved := NewBot(b.token, options...)
+	moved.HTTPClient = b.HTTPClient
+	moved.JSONMarshal = b.JSONMarshal
+	moved.JSONUnmarshal = b.JSONUnmarshal
+	moved.RetryPolicy = b.RetryPolicy
+	moved.Limiter = b.Limiter
+	moved.middlewares = append(moved.middlewares, b.middlewares...)
+	return moved, nil
+}
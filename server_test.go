@@ -0,0 +1,82 @@
+package telegram
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	record := func(r *http.Request) interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		return true
+	}
+
+	target := newTestBot(t, record)
+	bot := newTestBot(t, record, WithTestEnvironment())
+
+	moved, err := bot.Migrate(context.Background(), WithLocalServer(target.server))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !moved.IsTest() || !moved.IsLocal() {
+		t.Errorf("IsTest() = %v, IsLocal() = %v; want both", moved.IsTest(), moved.IsLocal())
+	}
+
+	if _, err := moved.CloseCtx(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"/bot123:TEST/test/logOut", "/bot123:TEST/test/close"}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("called %v, want %v", paths, want)
+	}
+}
+
+func TestMigrateLocal(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	source := newTestBot(t, func(r *http.Request) interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		return true
+	})
+
+	bot := NewBot("123:TEST", WithLocalServer(source.server))
+	bot.HTTPClient = source.HTTPClient
+
+	moved, err := bot.Migrate(context.Background(), WithLocalServer("http://other:8081"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !moved.IsLocal() || moved.IsTest() || moved.server != "http://other:8081/" {
+		t.Errorf("moved to %s, IsLocal() = %v, IsTest() = %v", moved.server, moved.IsLocal(), moved.IsTest())
+	}
+
+	// the webhook is deleted before close, so the server doesn't launch the
+	// bot again
+	want := []string{"/bot123:TEST/deleteWebhook", "/bot123:TEST/close"}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("called %v, want %v", paths, want)
+	}
+}
+
+func TestSizeLimits(t *testing.T) {
+	cloud, local := NewBot("123:TEST"), NewBot("123:TEST", WithLocalServer("http://localhost:8081"))
+
+	if cloud.MaxDownloadSize() != MaxDownloadSize || cloud.MaxUploadSize() != MaxUploadSize {
+		t.Errorf("cloud limits %d, %d", cloud.MaxDownloadSize(), cloud.MaxUploadSize())
+	}
+
+	if local.MaxDownloadSize() != 0 || local.MaxUploadSize() != LocalMaxUploadSize {
+		t.Errorf("local limits %d, %d", local.MaxDownloadSize(), local.MaxUploadSize())
+	}
+}
@@ -0,0 +1,159 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SessionKey identifies a conversation: a user in a chat.
+type SessionKey struct {
+	ChatID int64
+	UserID int64
+}
+
+func (k SessionKey) String() string {
+	return fmt.Sprintf("%d_%d", k.ChatID, k.UserID)
+}
+
+// SessionKeyOf returns the key of the conversation the update belongs to, ok is
+// false for updates without a chat or a user.
+func SessionKeyOf(update *Update) (key SessionKey, ok bool) {
+	chat, user := update.EffectiveChat(), update.EffectiveUser()
+	if chat == nil || user == nil {
+		return key, false
+	}
+
+	return SessionKey{ChatID: chat.Id, UserID: user.Id}, true
+}
+
+// Session is the state of a conversation with the data collected so far.
+type Session struct {
+	State     State             `json:"state"`
+	Data      map[string]string `json:"data,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// SessionStore keeps sessions of conversations. GetSession returns nil if
+// there is no session for the key.
+type SessionStore interface {
+	GetSession(ctx context.Context, key SessionKey) (*Session, error)
+	SaveSession(ctx context.Context, key SessionKey, session *Session) error
+	DeleteSession(ctx context.Context, key SessionKey) error
+}
+
+type MemorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[SessionKey]Session
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{sessions: map[SessionKey]Session{}}
+}
+
+func (s *MemorySessionStore) GetSession(ctx context.Context, key SessionKey) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[key]
+	if !ok {
+		return nil, nil
+	}
+
+	session.Data = copyData(session.Data)
+	return &session, nil
+}
+
+func (s *MemorySessionStore) SaveSession(ctx context.Context, key SessionKey, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sessions == nil {
+		s.sessions = map[SessionKey]Session{}
+	}
+
+	saved := *session
+	saved.Data = copyData(session.Data)
+	s.sessions[key] = saved
+	return nil
+}
+
+func (s *MemorySessionStore) DeleteSession(ctx context.Context, key SessionKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, key)
+	return nil
+}
+
+func copyData(data map[string]string) map[string]string {
+	if data == nil {
+		return nil
+	}
+
+	copied := make(map[string]string, len(data))
+	for k, v := range data {
+		copied[k] = v
+	}
+
+	return copied
+}
+
+// FileSessionStore keeps every session in a JSON file in Dir. Files are
+// replaced atomically.
+type FileSessionStore struct {
+	Dir string
+}
+
+func NewFileSessionStore(dir string) *FileSessionStore {
+	return &FileSessionStore{Dir: dir}
+}
+
+func (s *FileSessionStore) path(key SessionKey) string {
+	return filepath.Join(s.Dir, key.String()+".json")
+}
+
+func (s *FileSessionStore) GetSession(ctx context.Context, key SessionKey) (*Session, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{}
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *FileSessionStore) SaveSession(ctx context.Context, key SessionKey, session *Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.path(key), data)
+}
+
+func (s *FileSessionStore) DeleteSession(ctx context.Context, key SessionKey) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,298 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemorySessionStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySessionStore()
+	key := SessionKey{ChatID: -100, UserID: 1}
+
+	if session, err := store.GetSession(ctx, key); err != nil || session != nil {
+		t.Fatalf("GetSession() = %v, %v; want no session", session, err)
+	}
+
+	data := map[string]string{"a": "b"}
+	if err := store.SaveSession(ctx, key, &Session{State: "name", Data: data}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the store keeps copies, changes of the caller are not visible
+	data["a"] = "changed"
+	session, err := store.GetSession(ctx, key)
+	if err != nil || session == nil || session.State != "name" || session.Data["a"] != "b" {
+		t.Fatalf("GetSession() = %+v, %v", session, err)
+	}
+
+	session.Data["a"] = "changed"
+	if session, _ := store.GetSession(ctx, key); session.Data["a"] != "b" {
+		t.Errorf("the returned session shares data with the store")
+	}
+
+	if err := store.DeleteSession(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+
+	if session, err := store.GetSession(ctx, key); err != nil || session != nil {
+		t.Errorf("GetSession() after delete = %v, %v", session, err)
+	}
+
+	var zero MemorySessionStore
+	if err := zero.SaveSession(ctx, key, &Session{State: "name"}); err != nil {
+		t.Errorf("SaveSession() on a zero store = %v", err)
+	}
+}
+
+func TestFileSessionStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileSessionStore(filepath.Join(t.TempDir(), "sessions"))
+	key := SessionKey{ChatID: -100, UserID: 1}
+
+	if session, err := store.GetSession(ctx, key); err != nil || session != nil {
+		t.Fatalf("GetSession() = %v, %v; want no session", session, err)
+	}
+
+	if err := store.SaveSession(ctx, key, &Session{State: "name", Data: map[string]string{"a": "b"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := store.GetSession(ctx, key)
+	if err != nil || session == nil || session.State != "name" || session.Data["a"] != "b" {
+		t.Fatalf("GetSession() = %+v, %v", session, err)
+	}
+
+	assertFiles(t, store.Dir, key.String()+".json")
+
+	if err := store.DeleteSession(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+
+	if session, err := store.GetSession(ctx, key); err != nil || session != nil {
+		t.Errorf("GetSession() after delete = %v, %v", session, err)
+	}
+}
+
+func textUpdate(chatID, userID int64, text string) *Update {
+	return &Update{Message: &Message{
+		Chat: &Chat{Id: chatID},
+		From: &User{Id: userID},
+		Text: text,
+	}}
+}
+
+// newSignup returns a conversation asking for a name and an age; the text
+// "fail" makes the handlers fail and "lost" moves to an unknown state.
+func newSignup(store SessionStore) *Conversation {
+	c := NewConversation(store)
+	c.State("name", func(ctx context.Context, update *Update, session *Session) (State, error) {
+		if update.Message.Text == "fail" {
+			return "", errors.New("failure")
+		}
+
+		session.Data["name"] = update.Message.Text
+		return "age", nil
+	})
+	c.State("age", func(ctx context.Context, update *Update, session *Session) (State, error) {
+		if update.Message.Text == "lost" {
+			return "lost", nil
+		}
+
+		session.Data["age"] = update.Message.Text
+		return EndState, nil
+	})
+	return c
+}
+
+// passed returns a handler recording the updates passed on by a conversation.
+func passed(c *Conversation) (UpdateHandler, *[]string) {
+	var texts []string
+	handler := c.Middleware()(func(ctx context.Context, update *Update) error {
+		text := ""
+		if update.Message != nil {
+			text = update.Message.Text
+		}
+
+		texts = append(texts, text)
+		return nil
+	})
+	return handler, &texts
+}
+
+func TestConversation(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySessionStore()
+	c := newSignup(store)
+	handle, next := passed(c)
+	key := SessionKey{ChatID: 1, UserID: 2}
+
+	// without a session updates are passed on
+	if err := handle(ctx, textUpdate(1, 2, "/start")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle(ctx, &Update{UpdateId: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Start(ctx, key, "missing", nil); err == nil || !strings.Contains(err.Error(), `unknown conversation state "missing"`) {
+		t.Errorf("Start() = %v, want an unknown state error", err)
+	}
+
+	if err := c.Start(ctx, key, "name", map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a session of another user in the same chat doesn't catch the update
+	for _, update := range []*Update{textUpdate(1, 2, "Ann"), textUpdate(1, 3, "other")} {
+		if err := handle(ctx, update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	session, err := c.Session(ctx, key)
+	if err != nil || session == nil || session.State != "age" || session.Data["name"] != "Ann" {
+		t.Fatalf("Session() = %+v, %v; want age with the name", session, err)
+	}
+
+	if err := handle(ctx, textUpdate(1, 2, "30")); err != nil {
+		t.Fatal(err)
+	}
+
+	// EndState deletes the session
+	if session, err := store.GetSession(ctx, key); err != nil || session != nil {
+		t.Errorf("session after EndState = %+v, %v", session, err)
+	}
+
+	if want := []string{"/start", "", "other"}; strings.Join(*next, ",") != strings.Join(want, ",") {
+		t.Errorf("passed on %q, want %q", *next, want)
+	}
+
+	if err := c.Start(ctx, key, "name", map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.End(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+
+	if session, err := c.Session(ctx, key); err != nil || session != nil {
+		t.Errorf("Session() after End = %+v, %v", session, err)
+	}
+}
+
+func TestConversationErrors(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySessionStore()
+	c := newSignup(store)
+	handle, next := passed(c)
+	key := SessionKey{ChatID: 1, UserID: 2}
+
+	if err := c.Start(ctx, key, "name", map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a failed handler keeps the state
+	if err := handle(ctx, textUpdate(1, 2, "fail")); err == nil || err.Error() != "failure" {
+		t.Errorf("err = %v, want failure", err)
+	}
+
+	if session, _ := c.Session(ctx, key); session == nil || session.State != "name" {
+		t.Errorf("session after an error = %+v, want name", session)
+	}
+
+	if err := handle(ctx, textUpdate(1, 2, "Ann")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle(ctx, textUpdate(1, 2, "lost")); err == nil || !strings.Contains(err.Error(), `unknown conversation state "lost"`) {
+		t.Errorf("err = %v, want an unknown state error", err)
+	}
+
+	if session, _ := c.Session(ctx, key); session == nil || session.State != "age" {
+		t.Errorf("session after moving to an unknown state = %+v, want age", session)
+	}
+
+	// e.g. a state removed in a new version of the bot
+	if err := store.SaveSession(ctx, key, &Session{State: "removed", UpdatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle(ctx, textUpdate(1, 2, "text")); err == nil || !strings.Contains(err.Error(), `unknown conversation state "removed"`) {
+		t.Errorf("err = %v, want an unknown state error", err)
+	}
+
+	if len(*next) != 0 {
+		t.Errorf("passed on %q, want nothing", *next)
+	}
+}
+
+func TestConversationTimeout(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySessionStore()
+	c := newSignup(store)
+	c.Timeout = time.Minute
+	handle, next := passed(c)
+	key := SessionKey{ChatID: 1, UserID: 2}
+
+	expire := func() {
+		t.Helper()
+
+		err := store.SaveSession(ctx, key, &Session{State: "name", UpdatedAt: time.Now().Add(-2 * time.Minute)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expire()
+	if session, err := c.Session(ctx, key); err != nil || session != nil {
+		t.Errorf("Session() of an expired session = %+v, %v", session, err)
+	}
+
+	// without OnTimeout the update is passed on and the session deleted
+	if err := handle(ctx, textUpdate(1, 2, "late")); err != nil {
+		t.Fatal(err)
+	}
+
+	if session, _ := store.GetSession(ctx, key); session != nil {
+		t.Errorf("expired session %+v is kept", session)
+	}
+
+	var timedOut []SessionKey
+	c.OnTimeout = func(ctx context.Context, key SessionKey, session *Session) error {
+		timedOut = append(timedOut, key)
+		if session.State != "name" {
+			t.Errorf("OnTimeout got state %q, want name", session.State)
+		}
+		return nil
+	}
+
+	expire()
+	if err := handle(ctx, textUpdate(1, 2, "later")); err != nil {
+		t.Fatal(err)
+	}
+
+	// an error of OnTimeout is returned instead of passing the update on
+	c.OnTimeout = func(ctx context.Context, key SessionKey, session *Session) error {
+		timedOut = append(timedOut, key)
+		return errors.New("timeout")
+	}
+
+	expire()
+	if err := handle(ctx, textUpdate(1, 2, "latest")); err == nil || err.Error() != "timeout" {
+		t.Errorf("err = %v, want timeout", err)
+	}
+
+	if len(timedOut) != 2 || timedOut[0] != key {
+		t.Errorf("OnTimeout called for %v, want %v twice", timedOut, key)
+	}
+
+	if want := "late,later"; strings.Join(*next, ",") != want {
+		t.Errorf("passed on %q, want %s", *next, want)
+	}
+}
@@ -0,0 +1,57 @@
+package telegramtest
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Call is an API call received by the Server. Params holds the JSON encoded
+// parameters, both for JSON and multipart/form-data requests; uploaded files
+// are stored in Files by the name of the form field.
+type Call struct {
+	Method string
+	Params map[string]json.RawMessage
+	Files  map[string][]byte
+}
+
+// Has reports whether the parameter was sent.
+func (c *Call) Has(name string) bool {
+	_, ok := c.Params[name]
+	return ok
+}
+
+// Decode decodes the parameter into v.
+func (c *Call) Decode(name string, v interface{}) error {
+	return json.Unmarshal(c.Params[name], v)
+}
+
+// String returns a string parameter, or the JSON of any other one.
+func (c *Call) String(name string) string {
+	var s string
+	if err := c.Decode(name, &s); err == nil {
+		return s
+	}
+
+	return string(c.Params[name])
+}
+
+// Int returns an integer parameter, also if it was sent as a string.
+func (c *Call) Int(name string) int64 {
+	i, _ := strconv.ParseInt(strings.Trim(string(c.Params[name]), `"`), 10, 64)
+	return i
+}
+
+// File returns an uploaded file passed in the parameter, either directly or
+// with an attach:// reference.
+func (c *Call) File(name string) []byte {
+	if data, ok := c.Files[name]; ok {
+		return data
+	}
+
+	if ref := c.String(name); strings.HasPrefix(ref, "attach://") {
+		return c.Files[strings.TrimPrefix(ref, "attach://")]
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package telegramtest
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	telegram "github.com/iamdimka/go-telegram"
+)
+
+func (s *Server) getMe(call *Call) (interface{}, error) {
+	return s.Me(), nil
+}
+
+// getUpdates returns queued updates from offset, waiting up to timeout
+// seconds for new ones. Updates before offset are confirmed and dropped.
+func (s *Server) getUpdates(ctx context.Context, call *Call) (interface{}, error) {
+	offset, limit := call.Int("offset"), int(call.Int("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(call.Int("timeout"))*time.Second)
+	defer cancel()
+
+	var updates []*telegram.Update
+	s.wait(ctx, func() bool {
+		for len(s.updates) > 0 && s.updates[0].UpdateId < offset {
+			s.updates = s.updates[1:]
+		}
+
+		updates = s.updates
+		if len(updates) > limit {
+			updates = updates[:limit]
+		}
+
+		updates = append([]*telegram.Update{}, updates...)
+		return len(updates) > 0
+	})
+
+	return updates, nil
+}
+
+func (s *Server) chat(call *Call) (*telegram.Chat, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if username := call.String("chat_id"); strings.HasPrefix(username, "@") {
+		for _, chat := range s.chats {
+			if "@"+chat.Username == username {
+				return chat, nil
+			}
+		}
+	} else if chat := s.chats[call.Int("chat_id")]; chat != nil {
+		return chat, nil
+	}
+
+	return nil, badRequest("chat not found")
+}
+
+func (s *Server) getChat(call *Call) (interface{}, error) {
+	return s.chat(call)
+}
+
+func (s *Server) getFile(call *Call) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := s.files[call.String("file_id")]
+	if f == nil {
+		return nil, badRequest("invalid file_id")
+	}
+
+	return &f.file, nil
+}
+
+// send adds a message from the bot to the chat of the call.
+func (s *Server) send(call *Call) (*telegram.Message, error) {
+	chat, err := s.chat(call)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &telegram.Message{
+		From:    s.Me(),
+		Chat:    chat,
+		Date:    int(time.Now().Unix()),
+		Text:    call.String("text"),
+		Caption: call.String("caption"),
+	}
+
+	if call.Has("reply_markup") {
+		markup := &telegram.InlineKeyboardMarkup{}
+		if call.Decode("reply_markup", markup) == nil && markup.InlineKeyboard != nil {
+			message.ReplyMarkup = markup
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id := call.Int("reply_to_message_id"); id > 0 {
+		message.ReplyToMessage = s.message(chat.Id, id)
+	}
+
+	s.addMessage(chat, message)
+	sent := *message
+	return &sent, nil
+}
+
+func (s *Server) sendMessage(call *Call) (interface{}, error) {
+	if call.String("text") == "" {
+		return nil, badRequest("message text is empty")
+	}
+
+	return s.send(call)
+}
+
+// message returns the message by id, s.mu must be held.
+func (s *Server) message(chatID, messageID int64) *telegram.Message {
+	for _, m := range s.messages[chatID] {
+		if m.MessageId == messageID {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// editMessage implements editMessageText, editMessageCaption and
+// editMessageReplyMarkup of messages sent by the bot to chats.
+func (s *Server) editMessage(call *Call) (interface{}, error) {
+	if call.Has("inline_message_id") {
+		return true, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	message := s.message(call.Int("chat_id"), call.Int("message_id"))
+	if message == nil {
+		return nil, badRequest("message to edit not found")
+	}
+
+	edited := *message
+	switch call.Method {
+	case "editMessageText":
+		edited.Text = call.String("text")
+	case "editMessageCaption":
+		edited.Caption = call.String("caption")
+	}
+
+	edited.ReplyMarkup = nil
+	if call.Has("reply_markup") {
+		edited.ReplyMarkup = &telegram.InlineKeyboardMarkup{}
+		if err := call.Decode("reply_markup", edited.ReplyMarkup); err != nil {
+			return nil, badRequest("can't parse reply keyboard markup JSON object")
+		}
+	}
+
+	if reflect.DeepEqual(&edited, message) {
+		return nil, badRequest("message is not modified: specified new message content and reply markup are exactly the same as a current content and reply markup of the message")
+	}
+
+	edited.EditDate = int(time.Now().Unix())
+	*message = edited
+	s.notify()
+	return &edited, nil
+}
+
+func (s *Server) deleteMessage(call *Call) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chatID, messageID := call.Int("chat_id"), call.Int("message_id")
+	messages := s.messages[chatID]
+	for i, m := range messages {
+		if m.MessageId == messageID {
+			s.messages[chatID] = append(messages[:i:i], messages[i+1:]...)
+			s.notify()
+			return true, nil
+		}
+	}
+
+	return nil, badRequest("message to delete not found")
+}
+
+// defaultResult answers methods without a handler: methods returning Message
+// add a message to the chat, others return the zero value of their result,
+// true for booleans.
+func (s *Server) defaultResult(call *Call) (interface{}, error) {
+	resultType := botMethod(call.Method).Type().Out(0)
+
+	switch {
+	case resultType == reflect.TypeOf(&telegram.Message{}) && call.Has("chat_id"):
+		return s.send(call)
+	case resultType.Kind() == reflect.Bool:
+		return true, nil
+	case resultType.Kind() == reflect.Pointer:
+		return reflect.New(resultType.Elem()).Interface(), nil
+	case resultType.Kind() == reflect.Slice:
+		return reflect.MakeSlice(resultType, 0, 0).Interface(), nil
+	}
+
+	return reflect.Zero(resultType).Interface(), nil
+}
@@ -0,0 +1,300 @@
+package telegramtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// RedactedToken replaces the bot token in recorded interactions.
+const RedactedToken = "<TOKEN>"
+
+// Interaction is an API call recorded by a Recorder. Request is the
+// normalized request body: JSON with sorted keys, uploaded files are replaced
+// by their SHA-256 checksums.
+type Interaction struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Recorder is an http.RoundTripper recording API calls, e.g. for
+// telegram.Bot.HTTPClient. Requests other than API calls, like file downloads,
+// are passed through without recording.
+type Recorder struct {
+	Transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder records calls sent with transport, http.DefaultTransport if nil.
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{Transport: transport}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, method, ok := parseAPIPath(req.URL.Path)
+	if !ok {
+		return r.Transport.RoundTrip(req)
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := normalizeRequest(method, req, body, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// the request must not be modified, the transport gets a copy with the
+	// body read above
+	res, err := r.Transport.RoundTrip(withBody(req, body))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(data))
+
+	response := json.RawMessage(redact(data, token))
+	if !json.Valid(response) {
+		response, _ = json.Marshal(string(response))
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Method:   method,
+		Request:  request,
+		Status:   res.StatusCode,
+		Response: response,
+	})
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// Interactions returns the calls recorded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction{}, r.interactions...)
+}
+
+// Save writes the recorded calls to a fixture file.
+func (r *Recorder) Save(path string) error {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r.Interactions()); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// Replayer is an http.RoundTripper answering API calls with recorded
+// responses. A call is answered by the first unused interaction with the same
+// method and normalized request body.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+func NewReplayer(interactions []Interaction) *Replayer {
+	return &Replayer{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+}
+
+// LoadReplayer reads interactions saved with Recorder.Save.
+func LoadReplayer(path string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, err
+	}
+
+	return NewReplayer(interactions), nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, method, ok := parseAPIPath(req.URL.Path)
+	if !ok {
+		return nil, fmt.Errorf("telegramtest: can't replay %s", redact([]byte(req.URL.Path), token))
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := normalizeRequest(method, req, body, token)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, it := range r.interactions {
+		if r.used[i] || it.Method != method || !bytes.Equal(compact(it.Request), compact(request)) {
+			continue
+		}
+
+		r.used[i] = true
+		response := []byte(it.Response)
+		var text string
+		if json.Unmarshal(response, &text) == nil {
+			response = []byte(text)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", it.Status, http.StatusText(it.Status)),
+			StatusCode:    it.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": {"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader(bytes.ReplaceAll(response, []byte(RedactedToken), []byte(token)))),
+			ContentLength: -1,
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("telegramtest: no recorded %s call with request %s", method, request)
+}
+
+// Unused returns interactions which were not replayed.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unused := []Interaction{}
+	for i, it := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, it)
+		}
+	}
+
+	return unused
+}
+
+// parseAPIPath splits /bot<token>/<method> and /bot<token>/test/<method>.
+func parseAPIPath(path string) (token, method string, ok bool) {
+	i := strings.LastIndex(path, "/bot")
+	if i < 0 || strings.Contains(path[:i+1], "/file/") {
+		return "", "", false
+	}
+
+	token, method, ok = strings.Cut(path[i+len("/bot"):], "/")
+	method = strings.TrimPrefix(method, "test/")
+	return token, method, ok && token != "" && method != ""
+}
+
+// readBody reads and closes the request body.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	return body, err
+}
+
+// withBody returns a copy of req sending body.
+func withBody(req *http.Request, body []byte) *http.Request {
+	out := req.Clone(req.Context())
+	if req.Body == nil {
+		return out
+	}
+
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return out
+}
+
+func normalizeRequest(method string, req *http.Request, body []byte, token string) (json.RawMessage, error) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	call, err := readCall(method, withBody(req, body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, data := range call.Files {
+		sum := sha256.Sum256(data)
+		call.Params[name], _ = json.Marshal("sha256:" + hex.EncodeToString(sum[:]))
+	}
+
+	// decoded and encoded again, so keys of nested objects are sorted too
+	data, err := json.Marshal(call.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	var params interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(params); err != nil {
+		return nil, err
+	}
+
+	return redact(bytes.TrimSpace(buf.Bytes()), token), nil
+}
+
+func redact(data []byte, token string) []byte {
+	if token == "" {
+		return data
+	}
+
+	return bytes.ReplaceAll(data, []byte(token), []byte(RedactedToken))
+}
+
+func compact(data []byte) []byte {
+	buf := new(bytes.Buffer)
+	if json.Compact(buf, data) != nil {
+		return data
+	}
+
+	return buf.Bytes()
+}
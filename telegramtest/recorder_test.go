@@ -0,0 +1,132 @@
+package telegramtest_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	telegram "github.com/iamdimka/go-telegram"
+	"github.com/iamdimka/go-telegram/telegramtest"
+)
+
+type scenarioResult struct {
+	Me       *telegram.User
+	Message  *telegram.Message
+	Document *telegram.Message
+	Error    string
+}
+
+// scenario sends a JSON request, a multipart upload and a failing request.
+func scenario(t *testing.T, bot *telegram.Bot, chatID int64) *scenarioResult {
+	t.Helper()
+
+	ctx := context.Background()
+	result := &scenarioResult{}
+
+	var err error
+	if result.Me, err = bot.GetMeCtx(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	result.Message, err = bot.SendMessageCtx(ctx, &telegram.SendMessageRequest{ChatId: chatID, Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result.Document, err = bot.SendDocumentCtx(ctx, &telegram.SendDocumentRequest{
+		ChatId:   chatID,
+		Document: telegram.FileReader("report.txt", strings.NewReader("contents")),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = bot.SendMessageCtx(ctx, &telegram.SendMessageRequest{ChatId: 1, Text: "nobody"})
+	if err == nil {
+		t.Fatal("sent a message to a missing chat")
+	}
+
+	result.Error = err.Error()
+	return result
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv := telegramtest.NewServer()
+	user := &telegram.User{Id: 42, FirstName: "Ann"}
+	chat := telegramtest.PrivateChat(user)
+	srv.AddChat(chat)
+
+	recorder := telegramtest.NewRecorder(nil)
+	bot := telegram.NewBot(srv.Token, telegram.WithServer(srv.URL()))
+	bot.HTTPClient = &http.Client{Transport: recorder}
+	recorded := scenario(t, bot, chat.Id)
+	srv.Close()
+
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(data, []byte(srv.Token)) {
+		t.Errorf("the token is not redacted:\n%s", data)
+	}
+
+	replayer, err := telegramtest.LoadReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the server is closed, every call is answered by the replayer
+	bot.HTTPClient = &http.Client{Transport: replayer}
+	replayed := scenario(t, bot, chat.Id)
+
+	if !reflect.DeepEqual(replayed, recorded) {
+		t.Errorf("replayed %+v, recorded %+v", replayed, recorded)
+	}
+
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("unused interactions %v", unused)
+	}
+
+	// the same upload with another file doesn't match the recording
+	_, err = bot.SendDocumentCtx(context.Background(), &telegram.SendDocumentRequest{
+		ChatId:   chat.Id,
+		Document: telegram.FileReader("report.txt", strings.NewReader("other contents")),
+	})
+	if err == nil {
+		t.Error("replayed an upload of another file")
+	}
+}
+
+func TestRecorderKeepsRequest(t *testing.T) {
+	srv := telegramtest.NewServer()
+	defer srv.Close()
+
+	body := strings.NewReader(`{}`)
+	req, err := http.NewRequest(http.MethodPost, srv.URL()+"/bot"+srv.Token+"/getMe", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	reqBody, header := req.Body, req.Header.Clone()
+
+	res, err := telegramtest.NewRecorder(nil).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if req.Body != reqBody || req.ContentLength != 2 || !reflect.DeepEqual(req.Header, header) {
+		t.Errorf("the request was modified: %+v", req)
+	}
+}
@@ -0,0 +1,500 @@
+// Package telegramtest provides an in-process fake Bot API server to test bots
+// built with the telegram package, using the real telegram.Bot client.
+//
+// Tests inject messages and callback queries, which the bot receives with
+// getUpdates, and assert on the messages the bot sent and the calls it made.
+package telegramtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	telegram "github.com/iamdimka/go-telegram"
+)
+
+// Handler implements an API method. The result is encoded as the result of
+// the call, a *telegram.APIError is returned as an error response.
+type Handler func(call *Call) (interface{}, error)
+
+type file struct {
+	file telegram.File
+	data []byte
+}
+
+// Server is a fake Bot API server. Methods unknown to telegram.Bot are
+// answered with 404, methods without a built-in or custom handler succeed with
+// a zero result, or a new message for methods returning Message.
+type Server struct {
+	Token string
+
+	srv      *httptest.Server
+	me       telegram.User
+	handlers map[string]Handler
+
+	mu        sync.Mutex
+	changed   chan struct{}
+	calls     []*Call
+	updates   []*telegram.Update
+	updateID  int64
+	chats     map[int64]*telegram.Chat
+	messages  map[int64][]*telegram.Message
+	files     map[string]*file
+	callbacks int
+}
+
+// NewServer starts a server for the bot 123456:TEST (@test_bot). It must be
+// closed with Close.
+func NewServer() *Server {
+	s := &Server{
+		Token: "123456:TEST",
+		me: telegram.User{
+			Id:        123456,
+			IsBot:     true,
+			FirstName: "Test",
+			Username:  "test_bot",
+		},
+		changed:  make(chan struct{}),
+		chats:    map[int64]*telegram.Chat{},
+		messages: map[int64][]*telegram.Message{},
+		files:    map[string]*file{},
+	}
+
+	s.handlers = map[string]Handler{
+		"getMe":                  s.getMe,
+		"getUpdates":             nil, // long polling is handled by ServeHTTP
+		"getChat":                s.getChat,
+		"getFile":                s.getFile,
+		"sendMessage":            s.sendMessage,
+		"editMessageText":        s.editMessage,
+		"editMessageCaption":     s.editMessage,
+		"editMessageReplyMarkup": s.editMessage,
+		"deleteMessage":          s.deleteMessage,
+	}
+
+	s.srv = httptest.NewServer(s)
+	return s
+}
+
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// URL of the server, to be passed to telegram.WithServer.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Me returns the user of the bot.
+func (s *Server) Me() *telegram.User {
+	me := s.me
+	return &me
+}
+
+// Bot returns a client of the server.
+func (s *Server) Bot() *telegram.Bot {
+	bot := telegram.NewBot(s.Token, telegram.WithServer(s.URL()))
+	bot.HTTPClient = s.srv.Client()
+	return bot
+}
+
+// Handle overrides the implementation of an API method.
+func (s *Server) Handle(method string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// PrivateChat returns the private chat with user.
+func PrivateChat(user *telegram.User) *telegram.Chat {
+	return &telegram.Chat{
+		Id:        user.Id,
+		Type:      telegram.ChatTypePrivate,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Username:  user.Username,
+	}
+}
+
+// GroupChat returns a supergroup, id should be negative.
+func GroupChat(id int64, title string) *telegram.Chat {
+	return &telegram.Chat{
+		Id:    id,
+		Type:  telegram.ChatTypeSupergroup,
+		Title: title,
+	}
+}
+
+// AddChat makes the chat known to the server, so the bot can send messages to
+// it. Chats of injected updates are added automatically.
+func (s *Server) AddChat(chat *telegram.Chat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chats[chat.Id] = chat
+}
+
+// AddFile makes a file available with getFile and for download.
+func (s *Server) AddFile(fileID, filePath string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[fileID] = &file{
+		file: telegram.File{
+			FileId:       fileID,
+			FileUniqueId: fileID,
+			FileSize:     len(data),
+			FilePath:     filePath,
+		},
+		data: data,
+	}
+}
+
+// InjectUpdate queues the update for getUpdates, assigning its UpdateId.
+func (s *Server) InjectUpdate(update *telegram.Update) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if chat := update.EffectiveChat(); chat != nil && s.chats[chat.Id] == nil {
+		s.chats[chat.Id] = chat
+	}
+
+	s.updateID++
+	update.UpdateId = s.updateID
+	s.updates = append(s.updates, update)
+	s.notify()
+}
+
+// InjectMessage sends a message from user to the chat. A message starting with
+// "/" gets a bot_command entity.
+func (s *Server) InjectMessage(chat *telegram.Chat, from *telegram.User, text string) *telegram.Message {
+	message := &telegram.Message{
+		From: from,
+		Chat: chat,
+		Date: int(time.Now().Unix()),
+		Text: text,
+	}
+
+	if strings.HasPrefix(text, "/") {
+		command, _, _ := strings.Cut(text, " ")
+		message.Entities = []*telegram.MessageEntity{{
+			Type:   telegram.MessageEntityTypeBotCommand,
+			Length: len(utf16.Encode([]rune(command))),
+		}}
+	}
+
+	s.mu.Lock()
+	s.addMessage(chat, message)
+	s.mu.Unlock()
+
+	// a copy, as the stored message may be edited while the update is sent
+	injected := *message
+	s.InjectUpdate(&telegram.Update{Message: &injected})
+	return &injected
+}
+
+// InjectCallback presses a button with data under the message.
+func (s *Server) InjectCallback(from *telegram.User, message *telegram.Message, data string) *telegram.CallbackQuery {
+	s.mu.Lock()
+	s.callbacks++
+	query := &telegram.CallbackQuery{
+		Id:           strconv.Itoa(s.callbacks),
+		From:         from,
+		Message:      message,
+		ChatInstance: strconv.FormatInt(message.Chat.Id, 10),
+		Data:         data,
+	}
+	s.mu.Unlock()
+
+	s.InjectUpdate(&telegram.Update{CallbackQuery: query})
+	return query
+}
+
+// PendingUpdates returns the number of updates not confirmed by getUpdates.
+func (s *Server) PendingUpdates() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.updates)
+}
+
+// Messages returns all messages of the chat, sent by the bot and injected.
+func (s *Server) Messages(chatID int64) []*telegram.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyMessages(s.messages[chatID])
+}
+
+// copyMessages returns copies of messages, so they can be read while the bot
+// edits them.
+func copyMessages(messages []*telegram.Message) []*telegram.Message {
+	copies := make([]*telegram.Message, len(messages))
+	for i, m := range messages {
+		c := *m
+		copies[i] = &c
+	}
+
+	return copies
+}
+
+// Sent returns messages sent by the bot to the chat.
+func (s *Server) Sent(chatID int64) []*telegram.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sent(chatID)
+}
+
+func (s *Server) sent(chatID int64) []*telegram.Message {
+	sent := []*telegram.Message{}
+	for _, m := range s.messages[chatID] {
+		if m.From != nil && m.From.Id == s.me.Id {
+			sent = append(sent, m)
+		}
+	}
+
+	return copyMessages(sent)
+}
+
+// Calls returns calls of the method, or all calls if method is empty.
+func (s *Server) Calls(method string) []*Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.callsOf(method)
+}
+
+func (s *Server) callsOf(method string) []*Call {
+	calls := []*Call{}
+	for _, c := range s.calls {
+		if method == "" || c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+
+	return calls
+}
+
+// WaitCalls waits until the method is called at least n times.
+func (s *Server) WaitCalls(ctx context.Context, method string, n int) ([]*Call, error) {
+	var calls []*Call
+	err := s.wait(ctx, func() bool {
+		calls = s.callsOf(method)
+		return len(calls) >= n
+	})
+
+	return calls, err
+}
+
+// WaitSent waits until the bot sends at least n messages to the chat.
+func (s *Server) WaitSent(ctx context.Context, chatID int64, n int) ([]*telegram.Message, error) {
+	var sent []*telegram.Message
+	err := s.wait(ctx, func() bool {
+		sent = s.sent(chatID)
+		return len(sent) >= n
+	})
+
+	return sent, err
+}
+
+func (s *Server) wait(ctx context.Context, done func() bool) error {
+	for {
+		s.mu.Lock()
+		ok := done()
+		changed := s.changed
+		s.mu.Unlock()
+
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// notify wakes up waiting calls, s.mu must be held.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Server) addMessage(chat *telegram.Chat, message *telegram.Message) {
+	if s.chats[chat.Id] == nil {
+		s.chats[chat.Id] = chat
+	}
+
+	messages := s.messages[chat.Id]
+	message.MessageId = int64(len(messages) + 1)
+	s.messages[chat.Id] = append(messages, message)
+	s.notify()
+}
+
+// ServeHTTP serves API calls and file downloads, of bots in the test
+// environment too (/bot<token>/test/<method>).
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if path := strings.TrimPrefix(r.URL.Path, "/file/bot"+s.Token+"/"); path != r.URL.Path {
+		s.serveFile(w, r, strings.TrimPrefix(path, "test/"))
+		return
+	}
+
+	method := strings.TrimPrefix(r.URL.Path, "/bot"+s.Token+"/")
+	if method == r.URL.Path {
+		writeError(w, &telegram.APIError{ErrorCode: http.StatusUnauthorized, Description: "Unauthorized"})
+		return
+	}
+
+	method = strings.TrimPrefix(method, "test/")
+
+	if !knownMethod(method) {
+		writeError(w, &telegram.APIError{ErrorCode: http.StatusNotFound, Description: "Not Found"})
+		return
+	}
+
+	call, err := readCall(method, r)
+	if err != nil {
+		writeError(w, &telegram.APIError{ErrorCode: http.StatusBadRequest, Description: "Bad Request: " + err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	s.notify()
+	handler, ok := s.handlers[method]
+	s.mu.Unlock()
+
+	var result interface{}
+	switch {
+	case method == "getUpdates" && handler == nil:
+		result, err = s.getUpdates(r.Context(), call)
+	case ok:
+		result, err = handler(call)
+	default:
+		result, err = s.defaultResult(call)
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &telegram.ApiResult{Ok: true, Result: data})
+}
+
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request, path string) {
+	s.mu.Lock()
+	var found *file
+	for _, f := range s.files {
+		if f.file.FilePath == path {
+			found = f
+		}
+	}
+	s.mu.Unlock()
+
+	if found == nil {
+		writeError(w, &telegram.APIError{ErrorCode: http.StatusNotFound, Description: "Not Found"})
+		return
+	}
+
+	http.ServeContent(w, r, path, time.Time{}, bytes.NewReader(found.data))
+}
+
+// knownMethod reports whether telegram.Bot implements the method.
+func knownMethod(method string) bool {
+	return method != "" && botMethod(method).IsValid()
+}
+
+func botMethod(method string) reflect.Value {
+	name := strings.ToUpper(method[:1]) + method[1:] + "Ctx"
+	return reflect.ValueOf(&telegram.Bot{}).MethodByName(name)
+}
+
+func readCall(method string, r *http.Request) (*Call, error) {
+	call := &Call{
+		Method: method,
+		Params: map[string]json.RawMessage{},
+		Files:  map[string][]byte{},
+	}
+
+	if r.Body == nil || r.ContentLength == 0 {
+		return call, nil
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if err := json.NewDecoder(r.Body).Decode(&call.Params); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		return call, nil
+	}
+
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return call, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FileName() != "" {
+			call.Files[part.FormName()] = data
+			continue
+		}
+
+		// strings are sent unquoted
+		if json.Valid(data) {
+			call.Params[part.FormName()] = data
+		} else {
+			call.Params[part.FormName()], _ = json.Marshal(string(data))
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, result *telegram.ApiResult) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var apiErr *telegram.APIError
+	if !errors.As(err, &apiErr) {
+		apiErr = &telegram.APIError{ErrorCode: http.StatusInternalServerError, Description: err.Error()}
+	}
+
+	writeJSON(w, apiErr.ErrorCode, &telegram.ApiResult{
+		ErrorCode:   apiErr.ErrorCode,
+		Description: apiErr.Description,
+		Parameters:  apiErr.Parameters,
+	})
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &telegram.APIError{ErrorCode: http.StatusBadRequest, Description: "Bad Request: " + fmt.Sprintf(format, args...)}
+}
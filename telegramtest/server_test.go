@@ -0,0 +1,239 @@
+package telegramtest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	telegram "github.com/iamdimka/go-telegram"
+	"github.com/iamdimka/go-telegram/telegramtest"
+)
+
+// runBot starts a bot answering /start with a button, which edits the message
+// when pressed, and /report with a document.
+func runBot(t *testing.T, ctx context.Context, bot *telegram.Bot) {
+	t.Helper()
+
+	commands := telegram.NewCommandRouter(bot)
+	commands.Command("start", "Start", func(ctx context.Context, command *telegram.Command) error {
+		_, err := bot.SendMessageCtx(ctx, &telegram.SendMessageRequest{
+			ChatId: command.Message.Chat.Id,
+			Text:   "Confirm?",
+			ReplyMarkup: &telegram.InlineKeyboardMarkup{
+				InlineKeyboard: [][]*telegram.InlineKeyboardButton{{{Text: "Yes", CallbackData: "confirm"}}},
+			},
+		})
+		return err
+	})
+
+	commands.Command("report", "Report", func(ctx context.Context, command *telegram.Command) error {
+		_, err := bot.SendDocumentCtx(ctx, &telegram.SendDocumentRequest{
+			ChatId:   command.Message.Chat.Id,
+			Document: telegram.FileReader("report.txt", strings.NewReader("report of "+command.RawArgs)),
+		})
+		return err
+	})
+
+	d := telegram.NewDispatcher()
+	commands.Register(d)
+	d.OnCallbackQuery(func(ctx context.Context, query *telegram.CallbackQuery) error {
+		if _, err := bot.AnswerCallbackQueryCtx(ctx, &telegram.AnswerCallbackQueryRequest{CallbackQueryId: query.Id}); err != nil {
+			return err
+		}
+
+		_, err := bot.EditMessageTextCtx(ctx, &telegram.EditMessageTextRequest{
+			ChatId:    query.Message.Chat.Id,
+			MessageId: query.Message.MessageId,
+			Text:      "Confirmed",
+		})
+		return err
+	}, telegram.CallbackData("confirm"))
+
+	poller := bot.NewPoller(telegram.WithTimeout(1))
+	if err := poller.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() { done <- d.Run(ctx, poller.Updates()) }()
+
+	t.Cleanup(func() {
+		if err := poller.Stop(); err != nil {
+			t.Error(err)
+		}
+
+		if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+			t.Error(err)
+		}
+	})
+}
+
+func TestServerWithBot(t *testing.T) {
+	srv := telegramtest.NewServer()
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	bot := srv.Bot()
+	runBot(t, ctx, bot)
+
+	user := &telegram.User{Id: 42, FirstName: "Ann"}
+	chat := telegramtest.PrivateChat(user)
+	srv.InjectMessage(chat, user, "/start")
+
+	sent, err := srv.WaitSent(ctx, chat.Id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sent[0].Text != "Confirm?" || sent[0].ReplyMarkup == nil || sent[0].ReplyMarkup.InlineKeyboard[0][0].CallbackData != "confirm" {
+		t.Fatalf("sent %+v", sent[0])
+	}
+
+	query := srv.InjectCallback(user, sent[0], "confirm")
+	calls, err := srv.WaitCalls(ctx, "editMessageText", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if answers := srv.Calls("answerCallbackQuery"); len(answers) != 1 || answers[0].String("callback_query_id") != query.Id {
+		t.Errorf("answerCallbackQuery calls = %v", answers)
+	}
+
+	if calls[0].Int("message_id") != sent[0].MessageId {
+		t.Errorf("edited message %d, want %d", calls[0].Int("message_id"), sent[0].MessageId)
+	}
+
+	// the edit is stored; the same edit again is rejected like by Telegram
+	messages := srv.Messages(chat.Id)
+	if edited := messages[len(messages)-1]; edited.Text != "Confirmed" || edited.ReplyMarkup != nil || edited.EditDate == 0 {
+		t.Errorf("edited message %+v", edited)
+	}
+
+	_, err = bot.EditMessageTextCtx(ctx, &telegram.EditMessageTextRequest{
+		ChatId:    chat.Id,
+		MessageId: sent[0].MessageId,
+		Text:      "Confirmed",
+	})
+	if !telegram.IsMessageNotModified(err) {
+		t.Errorf("err = %v, want message is not modified", err)
+	}
+
+	srv.InjectMessage(chat, user, "/report today")
+	calls, err = srv.WaitCalls(ctx, "sendDocument", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data := string(calls[0].File("document")); data != "report of today" {
+		t.Errorf("uploaded %q", data)
+	}
+
+	if calls[0].Int("chat_id") != chat.Id {
+		t.Errorf("document sent to %d, want %d", calls[0].Int("chat_id"), chat.Id)
+	}
+
+	// all updates are confirmed by the next getUpdates of the poller
+	for srv.PendingUpdates() > 0 {
+		if ctx.Err() != nil {
+			t.Fatalf("%d updates not confirmed", srv.PendingUpdates())
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	srv := telegramtest.NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	bot := srv.Bot()
+
+	me, err := bot.GetMeCtx(ctx)
+	if err != nil || me.Username != "test_bot" {
+		t.Errorf("GetMe() = %+v, %v", me, err)
+	}
+
+	var apiErr *telegram.APIError
+	_, err = bot.SendMessageCtx(ctx, &telegram.SendMessageRequest{ChatId: 1, Text: "hi"})
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != 400 || !strings.Contains(apiErr.Description, "chat not found") {
+		t.Errorf("err = %v, want chat not found", err)
+	}
+
+	srv.Handle("getChatMemberCount", func(call *telegramtest.Call) (interface{}, error) {
+		return 3, nil
+	})
+
+	count, err := bot.GetChatMemberCountCtx(ctx, &telegram.GetChatMemberCountRequest{ChatId: 1})
+	if err != nil || count != 3 {
+		t.Errorf("GetChatMemberCount() = %d, %v", count, err)
+	}
+
+	other := telegram.NewBot("654321:OTHER", telegram.WithServer(srv.URL()))
+	if _, err := other.GetMeCtx(ctx); !errors.As(err, &apiErr) || apiErr.ErrorCode != 401 {
+		t.Errorf("err = %v, want 401 for an unknown token", err)
+	}
+}
+
+func TestServerFiles(t *testing.T) {
+	srv := telegramtest.NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	srv.AddFile("file-1", "documents/a.txt", []byte("contents"))
+
+	body, err := srv.Bot().DownloadFile(ctx, "file-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil || string(data) != "contents" {
+		t.Errorf("downloaded %q, %v", data, err)
+	}
+
+	if _, err := srv.Bot().DownloadFile(ctx, "missing"); err == nil {
+		t.Error("downloaded a missing file")
+	}
+}
+
+func TestServerTestEnvironment(t *testing.T) {
+	srv := telegramtest.NewServer()
+	defer srv.Close()
+
+	ctx := context.Background()
+	bot := telegram.NewBot(srv.Token, telegram.WithServer(srv.URL()), telegram.WithTestEnvironment())
+
+	me, err := bot.GetMeCtx(ctx)
+	if err != nil || me.Username != "test_bot" {
+		t.Fatalf("GetMe() = %+v, %v", me, err)
+	}
+
+	user := &telegram.User{Id: 42, FirstName: "Ann"}
+	chat := telegramtest.PrivateChat(user)
+	srv.AddChat(chat)
+	if _, err := bot.SendMessageCtx(ctx, &telegram.SendMessageRequest{ChatId: chat.Id, Text: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if sent := srv.Sent(chat.Id); len(sent) != 1 || sent[0].Text != "hi" {
+		t.Errorf("sent %v", sent)
+	}
+
+	srv.AddFile("file-1", "documents/a.txt", []byte("contents"))
+	body, err := bot.DownloadFile(ctx, "file-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	if data, err := io.ReadAll(body); err != nil || string(data) != "contents" {
+		t.Errorf("downloaded %q, %v", data, err)
+	}
+}
@@ -0,0 +1,48 @@
+package telegram
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnmarshalChatMember(t *testing.T) {
+	data := `{
+		"chat": {"id": -100, "type": "supergroup"},
+		"from": {"id": 1, "is_bot": false, "first_name": "A"},
+		"date": 1,
+		"old_chat_member": {"status": "member", "user": {"id": 2, "is_bot": false, "first_name": "B"}},
+		"new_chat_member": {"status": "ghost", "user": {"id": 2, "is_bot": false, "first_name": "B"}}
+	}`
+
+	var updated ChatMemberUpdated
+	if err := json.Unmarshal([]byte(data), &updated); err != nil {
+		t.Fatal(err)
+	}
+
+	if member, ok := updated.OldChatMember.(*ChatMemberMember); !ok || member.User.Id != 2 {
+		t.Errorf("old_chat_member = %#v, want *ChatMemberMember of user 2", updated.OldChatMember)
+	}
+
+	unknown, ok := updated.NewChatMember.(*UnknownChatMember)
+	if !ok {
+		t.Fatalf("new_chat_member = %#v, want *UnknownChatMember", updated.NewChatMember)
+	}
+
+	if unknown.Status != "ghost" {
+		t.Errorf("status = %q, want ghost", unknown.Status)
+	}
+
+	encoded, err := json.Marshal(unknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	if fields["status"] != "ghost" || fields["user"] == nil {
+		t.Errorf("marshaled unknown member = %s", encoded)
+	}
+}
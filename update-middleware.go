@@ -0,0 +1,90 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// UpdateMiddleware wraps update handlers, e.g. to recover panics, authorize
+// users or log updates.
+type UpdateMiddleware func(next UpdateHandler) UpdateHandler
+
+// Chain wraps handler with middlewares, the first middleware is the outermost.
+func Chain(handler UpdateHandler, middlewares ...UpdateMiddleware) UpdateHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// PanicError is returned by handlers wrapped with Recover when they panic.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("telegram: handler panicked: %v", e.Value)
+}
+
+// Recover turns panics of the handler into a *PanicError.
+func Recover() UpdateMiddleware {
+	return func(next UpdateHandler) UpdateHandler {
+		return func(ctx context.Context, update *Update) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = &PanicError{Value: r, Stack: debug.Stack()}
+				}
+			}()
+
+			return next(ctx, update)
+		}
+	}
+}
+
+// Filter silently drops updates not matching the predicate.
+func Filter(predicate Predicate) UpdateMiddleware {
+	return func(next UpdateHandler) UpdateHandler {
+		return func(ctx context.Context, update *Update) error {
+			if !predicate(update) {
+				return nil
+			}
+
+			return next(ctx, update)
+		}
+	}
+}
+
+// AllowUsers drops updates from users not in the list, and updates without a
+// user.
+func AllowUsers(ids ...int64) UpdateMiddleware {
+	return Filter(UserID(ids...))
+}
+
+// Timing calls observe with the time spent handling every update.
+func Timing(observe func(update *Update, duration time.Duration, err error)) UpdateMiddleware {
+	return func(next UpdateHandler) UpdateHandler {
+		return func(ctx context.Context, update *Update) error {
+			start := time.Now()
+			err := next(ctx, update)
+			observe(update, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// LogUpdates logs the id, type and handling time of every update, e.g. with
+// log.Printf.
+func LogUpdates(logf func(format string, args ...interface{})) UpdateMiddleware {
+	return Timing(func(update *Update, duration time.Duration, err error) {
+		if err != nil {
+			logf("update %d (%s) failed in %s: %v", update.UpdateId, update.Type(), duration, err)
+			return
+		}
+
+		logf("update %d (%s) handled in %s", update.UpdateId, update.Type(), duration)
+	})
+}
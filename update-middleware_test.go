@@ -0,0 +1,154 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecover(t *testing.T) {
+	handler := Recover()(func(ctx context.Context, update *Update) error {
+		panic("boom")
+	})
+
+	err := handler(context.Background(), &Update{})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("err = %v, want *PanicError", err)
+	}
+
+	if panicErr.Value != "boom" || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("PanicError = %v", panicErr)
+	}
+
+	// the stack is taken where the handler panicked
+	if !bytes.Contains(panicErr.Stack, []byte("TestRecover")) {
+		t.Errorf("stack doesn't contain the handler:\n%s", panicErr.Stack)
+	}
+
+	failure := errors.New("failure")
+	handler = Recover()(func(ctx context.Context, update *Update) error {
+		return failure
+	})
+
+	if err := handler(context.Background(), &Update{}); err != failure {
+		t.Errorf("err = %v, want the error of the handler", err)
+	}
+}
+
+func TestAllowUsers(t *testing.T) {
+	var handled []*Update
+	handler := AllowUsers(1, 2)(func(ctx context.Context, update *Update) error {
+		handled = append(handled, update)
+		return nil
+	})
+
+	allowed := &Update{Message: chatMessage(ChatTypePrivate, 2, "hi")}
+	for _, update := range []*Update{
+		allowed,
+		{Message: chatMessage(ChatTypePrivate, 3, "hi")},
+		// e.g. a channel post, there is no user to authorize
+		{ChannelPost: &Message{Chat: &Chat{Type: ChatTypeChannel}}},
+		{},
+	} {
+		if err := handler(context.Background(), update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(handled) != 1 || handled[0] != allowed {
+		t.Errorf("handled %v, want only the update of an allowed user", handled)
+	}
+}
+
+// tracing returns a middleware recording when it is entered and left.
+func tracing(trace *[]string, name string) UpdateMiddleware {
+	return func(next UpdateHandler) UpdateHandler {
+		return func(ctx context.Context, update *Update) error {
+			*trace = append(*trace, name+" in")
+			err := next(ctx, update)
+			*trace = append(*trace, name+" out")
+			return err
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	var trace []string
+	handler := Chain(func(ctx context.Context, update *Update) error {
+		trace = append(trace, "handler")
+		return nil
+	}, tracing(&trace, "a"), tracing(&trace, "b"))
+
+	if err := handler(context.Background(), &Update{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := strings.Join(trace, ", "), "a in, b in, handler, b out, a out"; got != want {
+		t.Errorf("trace %s, want %s", got, want)
+	}
+}
+
+func TestDispatcherUse(t *testing.T) {
+	var trace []string
+	d := NewDispatcher()
+	d.OnMessage(func(ctx context.Context, message *Message) error {
+		trace = append(trace, "message")
+		return nil
+	})
+	d.NotHandled = func(ctx context.Context, update *Update) error {
+		trace = append(trace, "not handled")
+		panic("not handled")
+	}
+	d.Use(tracing(&trace, "a"), Recover())
+	d.Use(tracing(&trace, "b"))
+
+	ctx := context.Background()
+	if err := d.HandleUpdate(ctx, &Update{Message: chatMessage(ChatTypePrivate, 1, "hi")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// NotHandled is wrapped too, its panic is recovered
+	var panicErr *PanicError
+	if err := d.HandleUpdate(ctx, &Update{}); !errors.As(err, &panicErr) {
+		t.Errorf("err = %v, want *PanicError", err)
+	}
+
+	want := "a in, b in, message, b out, a out, a in, b in, not handled, a out"
+	if got := strings.Join(trace, ", "); got != want {
+		t.Errorf("trace %s, want %s", got, want)
+	}
+}
+
+func TestLogUpdates(t *testing.T) {
+	var lines []string
+	logf := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	var durations []time.Duration
+	handler := Chain(func(ctx context.Context, update *Update) error {
+		if update.UpdateId == 2 {
+			return errors.New("failure")
+		}
+		return nil
+	}, LogUpdates(logf), Timing(func(update *Update, duration time.Duration, err error) {
+		durations = append(durations, duration)
+	}))
+
+	handler(context.Background(), &Update{UpdateId: 1, Message: &Message{}})
+	handler(context.Background(), &Update{UpdateId: 2, CallbackQuery: &CallbackQuery{}})
+
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "update 1 (message) handled in ") ||
+		!strings.HasPrefix(lines[1], "update 2 (callback_query) failed in ") || !strings.HasSuffix(lines[1], ": failure") {
+		t.Errorf("logged %q", lines)
+	}
+
+	if len(durations) != 2 {
+		t.Errorf("Timing observed %d updates, want 2", len(durations))
+	}
+}
@@ -0,0 +1,112 @@
+package telegram
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// MaxWebhookBodySize limits the size of a request body accepted by Webhook.
+const MaxWebhookBodySize = 1 << 20
+
+// Webhook is an http.Handler receiving updates sent by Telegram to the URL
+// registered with SetWebhook. Received updates are delivered to the channel
+// returned by Updates, the same way PollUpdates does.
+type Webhook struct {
+	bot     *Bot
+	secret  string
+	updates chan *Update
+	done    chan struct{}
+
+	mu       sync.RWMutex
+	closed   bool
+	inFlight sync.WaitGroup
+}
+
+// WebhookHandler creates a webhook handler. If secret is not empty, requests
+// must carry the same value in the X-Telegram-Bot-Api-Secret-Token header
+// (see SetWebhookRequest.SecretToken).
+func (b *Bot) WebhookHandler(secret string) *Webhook {
+	return &Webhook{
+		bot:     b,
+		secret:  secret,
+		updates: make(chan *Update, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+func (w *Webhook) Updates() <-chan *Update {
+	return w.updates
+}
+
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Set("Allow", http.MethodPost)
+		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if w.secret != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(SecretTokenHeader)), []byte(w.secret)) != 1 {
+		http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(rw, r.Body, MaxWebhookBodySize))
+	if err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		http.Error(rw, http.StatusText(status), status)
+		return
+	}
+
+	update := &Update{}
+	if err = w.bot.JSONUnmarshal(data, update); err != nil {
+		http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	w.mu.RLock()
+	if w.closed {
+		w.mu.RUnlock()
+		http.Error(rw, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	w.inFlight.Add(1)
+	w.mu.RUnlock()
+	defer w.inFlight.Done()
+
+	select {
+	case w.updates <- update:
+		rw.WriteHeader(http.StatusOK)
+
+	case <-w.done:
+		http.Error(rw, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+	case <-r.Context().Done():
+	}
+}
+
+// Close stops accepting updates and closes the updates channel. Updates which
+// were not delivered yet are rejected, so Telegram will send them again.
+func (w *Webhook) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.done)
+	w.mu.Unlock()
+
+	w.inFlight.Wait()
+	close(w.updates)
+	return nil
+}
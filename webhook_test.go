@@ -0,0 +1,51 @@
+package telegram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookRejectsLargeBody(t *testing.T) {
+	webhook := NewBot("123:TEST").WebhookHandler("")
+	defer webhook.Close()
+
+	body := `{"update_id":1,"message":{"text":"` + strings.Repeat("a", MaxWebhookBodySize) + `"}}`
+	rec := httptest.NewRecorder()
+	webhook.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestWebhookSecret(t *testing.T) {
+	webhook := NewBot("123:TEST").WebhookHandler("secret")
+
+	rec := httptest.NewRecorder()
+	webhook.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id":1}`)))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	go func() {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id":2}`))
+		req.Header.Set(SecretTokenHeader, "secret")
+		rec := httptest.NewRecorder()
+		webhook.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		webhook.Close()
+	}()
+
+	update := <-webhook.Updates()
+	if update == nil || update.UpdateId != 2 {
+		t.Errorf("update = %+v, want update 2", update)
+	}
+
+	// closed by the goroutine after its checks
+	for range webhook.Updates() {
+	}
+}
@@ -0,0 +1,136 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+)
+
+// UpdateKey returns the key updates are serialized by in a WorkerPool: the id
+// of the chat the update belongs to, or of the user who caused it. Updates
+// without chat and user get their own key.
+func UpdateKey(update *Update) int64 {
+	if chat := update.EffectiveChat(); chat != nil {
+		return chat.Id
+	}
+
+	if user := update.EffectiveUser(); user != nil {
+		return user.Id
+	}
+
+	return update.UpdateId
+}
+
+// WorkerPool handles updates concurrently, while updates with the same key
+// (see UpdateKey) are handled one by one in the order they were received.
+// Every worker has a bounded queue; when it is full, Run stops reading
+// updates, which in turn blocks the poller.
+type WorkerPool struct {
+	Workers   int
+	QueueSize int
+	Handler   UpdateHandler
+
+	// Key returns the serialization key of an update, UpdateKey by default
+	Key func(update *Update) int64
+	// OnError is called with errors returned by Handler. When it is nil Run
+	// stops and returns the first error
+	OnError func(update *Update, err error)
+	// OnDone is called after an update is handled, even if with an error,
+	// e.g. Poller.Ack
+	OnDone func(update *Update)
+}
+
+func NewWorkerPool(workers int, handler UpdateHandler) *WorkerPool {
+	return &WorkerPool{
+		Workers:   workers,
+		QueueSize: 16,
+		Handler:   handler,
+	}
+}
+
+// Run handles updates until the channel is closed and all queued updates are
+// handled, or until ctx is done.
+func (w *WorkerPool) Run(ctx context.Context, updates <-chan *Update) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := w.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	key := w.Key
+	if key == nil {
+		key = UpdateKey
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	queues := make([]chan *Update, workers)
+	for i := range queues {
+		queues[i] = make(chan *Update, w.QueueSize)
+
+		wg.Add(1)
+		go func(queue <-chan *Update) {
+			defer wg.Done()
+
+			for update := range queue {
+				// skipped updates are not passed to OnDone, so they are not acknowledged
+				if ctx.Err() != nil {
+					continue
+				}
+
+				if err := w.Handler(ctx, update); err != nil {
+					if w.OnError != nil {
+						w.OnError(update, err)
+					} else {
+						errOnce.Do(func() {
+							firstErr = err
+							cancel()
+						})
+					}
+				}
+
+				if w.OnDone != nil {
+					w.OnDone(update)
+				}
+			}
+		}(queues[i])
+	}
+
+	w.feed(ctx, updates, queues, key)
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+func (w *WorkerPool) feed(ctx context.Context, updates <-chan *Update, queues []chan *Update, key func(update *Update) int64) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+
+			select {
+			case queues[uint64(key(update))%uint64(len(queues))] <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func chatUpdate(id, chatID int64) *Update {
+	return &Update{
+		UpdateId: id,
+		Message:  &Message{Chat: &Chat{Id: chatID}},
+	}
+}
+
+func TestWorkerPoolOrderPerChat(t *testing.T) {
+	var mu sync.Mutex
+	handled := map[int64][]int64{}
+	pool := NewWorkerPool(4, func(ctx context.Context, update *Update) error {
+		// later updates of other chats overtake slow ones
+		time.Sleep(time.Duration(update.UpdateId%3) * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		chatID := update.Message.Chat.Id
+		handled[chatID] = append(handled[chatID], update.UpdateId)
+		return nil
+	})
+
+	updates := make(chan *Update)
+	go func() {
+		for i := int64(0); i < 100; i++ {
+			updates <- chatUpdate(i, i%7)
+		}
+		close(updates)
+	}()
+
+	if err := pool.Run(context.Background(), updates); err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0
+	for chatID, ids := range handled {
+		total += len(ids)
+		for i := 1; i < len(ids); i++ {
+			if ids[i] < ids[i-1] {
+				t.Errorf("chat %d: updates handled in order %v", chatID, ids)
+				break
+			}
+		}
+	}
+
+	if total != 100 {
+		t.Errorf("%d updates handled, want 100", total)
+	}
+}
+
+func TestWorkerPoolConcurrency(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	pool := NewWorkerPool(2, func(ctx context.Context, update *Update) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	updates := make(chan *Update, 2)
+	updates <- chatUpdate(1, 0)
+	updates <- chatUpdate(2, 1)
+	close(updates)
+
+	done := make(chan error)
+	go func() { done <- pool.Run(context.Background(), updates) }()
+
+	// both chats are handled at the same time
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("updates of different chats are not handled concurrently")
+		}
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorkerPoolBackpressure(t *testing.T) {
+	release := make(chan struct{})
+	pool := NewWorkerPool(1, func(ctx context.Context, update *Update) error {
+		<-release
+		return nil
+	})
+	pool.QueueSize = 2
+
+	updates := make(chan *Update)
+	done := make(chan error)
+	go func() { done <- pool.Run(context.Background(), updates) }()
+
+	// one update is handled, two are queued and one is held by the feeder
+	for i := int64(0); i < 4; i++ {
+		updates <- chatUpdate(i, 1)
+	}
+
+	select {
+	case updates <- chatUpdate(4, 1):
+		t.Fatal("an update was read while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	updates <- chatUpdate(4, 1)
+	close(updates)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorkerPoolErrors(t *testing.T) {
+	failure := errors.New("failure")
+	handler := func(ctx context.Context, update *Update) error {
+		if update.UpdateId == 1 {
+			return failure
+		}
+		return nil
+	}
+
+	feed := func() <-chan *Update {
+		updates := make(chan *Update, 3)
+		for i := int64(0); i < 3; i++ {
+			updates <- chatUpdate(i, 1)
+		}
+		close(updates)
+		return updates
+	}
+
+	pool := NewWorkerPool(1, handler)
+	if err := pool.Run(context.Background(), feed()); err != failure {
+		t.Errorf("err = %v, want %v", err, failure)
+	}
+
+	var failed, done []int64
+	pool.OnError = func(update *Update, err error) {
+		failed = append(failed, update.UpdateId)
+	}
+	pool.OnDone = func(update *Update) {
+		done = append(done, update.UpdateId)
+	}
+
+	if err := pool.Run(context.Background(), feed()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(failed) != 1 || failed[0] != 1 || len(done) != 3 {
+		t.Errorf("failed %v, done %v; want [1] and all 3 updates", failed, done)
+	}
+}